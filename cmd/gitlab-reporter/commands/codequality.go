@@ -2,10 +2,8 @@ package commands
 
 import (
 	"bytes"
-	"encoding/xml"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,37 +25,126 @@ type CodeQualityCommand struct {
 	sourceReport   []string
 	reporterEngine []string
 	reportType     []string
+	reportFormat   []string
 	outputFile     string
 	outputArg      bool
 	detectReport   bool
+	baseline       string
+	onlyNew        bool
+	qualityGate    *model.QualityGate
+	diffBase       string
+	diffFile       string
+	diffWholeFiles bool
 }
 
-func NewCodeQualityCommand(flags *pflag.FlagSet) *CodeQualityCommand {
+func NewCodeQualityCommand(flags *pflag.FlagSet) (*CodeQualityCommand, error) {
 	codeQualityCommand := CodeQualityCommand{}
 
 	codeQualityCommand.sourceReport, _ = flags.GetStringSlice("source-report")
 	codeQualityCommand.reporterEngine, _ = flags.GetStringSlice("reporter-tool")
 	codeQualityCommand.reportType, _ = flags.GetStringSlice("report-type")
+	codeQualityCommand.reportFormat, _ = flags.GetStringSlice("report-format")
 	codeQualityCommand.outputFile, _ = flags.GetString("output-file")
 	codeQualityCommand.outputArg, _ = flags.GetBool("output")
 	codeQualityCommand.detectReport, _ = flags.GetBool("detect-report")
+	codeQualityCommand.baseline, _ = flags.GetString("baseline")
+	codeQualityCommand.onlyNew, _ = flags.GetBool("only-new")
+	codeQualityCommand.diffBase, _ = flags.GetString("diff-base")
+	codeQualityCommand.diffFile, _ = flags.GetString("diff-file")
+	codeQualityCommand.diffWholeFiles, _ = flags.GetBool("diff-whole-files")
+
+	failOnSeverity, _ := flags.GetString("fail-on-severity")
+	maxIssues, _ := flags.GetInt("max-issues")
+	maxCategoryIssues, _ := flags.GetStringToInt("max-category-issues")
+
+	qualityGate, err := model.NewQualityGate(failOnSeverity, maxIssues, maxCategoryIssues)
+	if err != nil {
+		return nil, err
+	}
+	codeQualityCommand.qualityGate = qualityGate
 
-	return &codeQualityCommand
+	return &codeQualityCommand, nil
 }
 
 func (t *CodeQualityCommand) FindReport(reportLocation string) []string {
-	matches, _ := filepath.Glob("*-checkstyle.xml")
+	matches, _ := filepath.Glob(reportLocation)
 	return matches
 }
 
 func (t *CodeQualityCommand) AddReport(reportFile string, reportType string, reportEngine string) *CodeQualityCommand {
+	// Keep the per report lists aligned with the source reports
+	for len(t.reporterEngine) < len(t.sourceReport) {
+		t.reporterEngine = append(t.reporterEngine, "")
+	}
+	for len(t.reportType) < len(t.sourceReport) {
+		t.reportType = append(t.reportType, model.ReportTypeIssue)
+	}
+	for len(t.reportFormat) < len(t.sourceReport) {
+		t.reportFormat = append(t.reportFormat, "")
+	}
+
 	t.reporterEngine = append(t.reporterEngine, reportEngine)
 	t.reportType = append(t.reportType, reportType)
+	t.reportFormat = append(t.reportFormat, "")
 	t.sourceReport = append(t.sourceReport, reportFile)
 
 	return t
 }
 
+// ReportType returns the type of the report at idx, defaulting to an issue
+func (t *CodeQualityCommand) ReportType(idx int) string {
+	if idx < len(t.reportType) && t.reportType[idx] != "" {
+		return t.reportType[idx]
+	}
+
+	return model.ReportTypeIssue
+}
+
+// ReporterEngine returns the engine of the report at idx, if any was specified
+func (t *CodeQualityCommand) ReporterEngine(idx int) string {
+	if idx < len(t.reporterEngine) {
+		return t.reporterEngine[idx]
+	}
+
+	return ""
+}
+
+// Parser returns the parser for the report at idx, detecting the format from the content when not specified
+func (t *CodeQualityCommand) Parser(idx int, reportData []byte) (model.Parser, error) {
+	options := model.ParserOptions{
+		ReportType:   t.ReportType(idx),
+		ReportEngine: t.ReporterEngine(idx),
+	}
+
+	if idx < len(t.reportFormat) && t.reportFormat[idx] != "" {
+		return model.NewParser(t.reportFormat[idx], options)
+	}
+
+	return model.DetectParser(reportData, options)
+}
+
+// CompareBaseline classifies the reports against the baseline report and prints a summary of the fixed issues
+func (t *CodeQualityCommand) CompareBaseline(reports []*model.Report) (*model.BaselineComparison, error) {
+	baselineFromFile, err := os.ReadFile(t.baseline)
+	if err != nil {
+		return nil, errors.New("specified baseline report was not found")
+	}
+
+	baselineReport, err := model.ReportListFromJSON(baselineFromFile)
+	if err != nil {
+		return nil, errors.New("could not parse the baseline file, it must be a code climate json report")
+	}
+
+	comparison := model.CompareBaseline(reports, baselineReport)
+
+	fmt.Printf("Baseline comparison: new (%d) unchanged (%d) fixed (%d)\n", len(comparison.New), len(comparison.Unchanged), len(comparison.Fixed))
+	for _, fixedReport := range comparison.Fixed {
+		fmt.Printf("Fixed issue: %s:%d %s (%s)\n", fixedReport.Location.Path, fixedReport.Location.Positions.Begin.Line, fixedReport.Description, fixedReport.CheckName)
+	}
+
+	return comparison, nil
+}
+
 func (t *CodeQualityCommand) CreateFile(fileData []byte) error {
 	f, errCreate := os.Create(t.outputFile)
 
@@ -80,15 +167,27 @@ func init() {
 	CodeQualityCmd.Flags().StringSlice("source-report", []string{""}, "Source Report")
 	CodeQualityCmd.Flags().StringSlice("reporter-tool", []string{""}, "Reporter Tool")
 	CodeQualityCmd.Flags().StringSlice("report-type", []string{model.ReportTypeIssue}, "Report Type")
+	CodeQualityCmd.Flags().StringSlice("report-format", []string{}, "Report Format ("+strings.Join(model.ParserNames(), ", ")+"), detected from the content when empty")
 	CodeQualityCmd.Flags().Bool("output", true, "Output")
 	CodeQualityCmd.Flags().Bool("debug", false, "Enables debug mode")
 	CodeQualityCmd.Flags().Bool("detect-report", true, "Automatically detect report files")
 	CodeQualityCmd.Flags().String("output-file", "", "Output File Name")
+	CodeQualityCmd.Flags().String("baseline", "", "Baseline code climate report to compare issues with, e.g. from the default branch")
+	CodeQualityCmd.Flags().Bool("only-new", false, "Only output issues not present in the baseline report")
+	CodeQualityCmd.Flags().String("diff-base", "", "Only report issues on lines changed between this git ref and HEAD")
+	CodeQualityCmd.Flags().String("diff-file", "", "Only report issues on lines changed by this unified diff file")
+	CodeQualityCmd.Flags().Bool("diff-whole-files", false, "Report every issue of the files changed by the diff")
+	CodeQualityCmd.Flags().String("fail-on-severity", "", "Fail when an issue has this severity or a higher one (info, minor, major, critical, blocker)")
+	CodeQualityCmd.Flags().Int("max-issues", -1, "Fail when there are more issues, a negative value disables the check")
+	CodeQualityCmd.Flags().StringToInt("max-category-issues", map[string]int{}, "Fail when a category has more issues, e.g. \"Bug Risk=0,Security=0\"")
 	RootCmd.AddCommand(CodeQualityCmd)
 }
 
 func codeQualityCmdF(command *cobra.Command, args []string) error {
-	transformCommand := NewCodeQualityCommand(command.Flags())
+	transformCommand, err := NewCodeQualityCommand(command.Flags())
+	if err != nil {
+		return err
+	}
 
 	parsedReport := make([]*model.Report, 0)
 
@@ -100,32 +199,68 @@ func codeQualityCmdF(command *cobra.Command, args []string) error {
 			splitFileName := strings.Split(reportFile, "-")
 			transformCommand = transformCommand.AddReport(reportFile, model.ReportTypeIssue, splitFileName[0])
 		}
+
+		for _, reportFile := range transformCommand.FindReport("*.sarif") {
+			fmt.Printf("Detected report: file (%s)\n", reportFile)
+			// The engine is taken from the tool driver of each run
+			transformCommand = transformCommand.AddReport(reportFile, model.ReportTypeIssue, "")
+		}
 	}
 
 	for idx, report := range transformCommand.sourceReport {
-		fmt.Printf("Using report: file (%s) type (%s) engine (%s)\n", report, transformCommand.reportType[idx], transformCommand.reporterEngine[idx])
+		if report == "" {
+			continue
+		}
+
+		fmt.Printf("Using report: file (%s) type (%s) engine (%s)\n", report, transformCommand.ReportType(idx), transformCommand.ReporterEngine(idx))
 
 		reportFromFile, err := os.ReadFile(report)
 		if err != nil {
 			return errors.New("specified source report was not found")
 		}
 
-		// Read our opened xmlFile as a byte array.
-		byteValue, _ := ioutil.ReadAll(bytes.NewReader(reportFromFile))
+		if model.IsEmptyReport(reportFromFile) {
+			continue
+		}
+
+		parser, err := transformCommand.Parser(idx, reportFromFile)
+		if err != nil {
+			return err
+		}
+
+		reports, err := parser.Parse(bytes.NewReader(reportFromFile))
+		if err != nil {
+			return err
+		}
+
+		parsedReport = append(parsedReport, reports...)
+	}
 
-		var result model.CheckStyleResult
-		err = xml.Unmarshal(byteValue, &result)
+	model.ComputeFingerprints(parsedReport)
 
+	// With a baseline only the new issues are subject to the quality gate
+	gatedReport := parsedReport
+	if transformCommand.baseline != "" {
+		comparison, err := transformCommand.CompareBaseline(parsedReport)
 		if err != nil {
-			return errors.New("could not parse the provided file, it must be xml checkstyle compliant")
+			return err
 		}
 
-		// Assemble Gitlab report compatible structure
-		for _, file := range result.Files {
-			for _, fileCheckStyleError := range file.Errors {
-				parsedReport = append(parsedReport, model.NewReportFromCheckstyle(fileCheckStyleError, transformCommand.reportType[idx], transformCommand.reporterEngine[idx], file.Name))
-			}
+		gatedReport = comparison.New
+		if transformCommand.onlyNew {
+			parsedReport = comparison.New
+		}
+	}
+
+	if transformCommand.diffBase != "" || transformCommand.diffFile != "" {
+		diff, err := readDiff(transformCommand.diffFile, transformCommand.diffBase)
+		if err != nil {
+			return err
 		}
+
+		parsedReport = model.FilterReportsByDiff(parsedReport, diff, transformCommand.diffWholeFiles)
+		gatedReport = model.FilterReportsByDiff(gatedReport, diff, transformCommand.diffWholeFiles)
+		fmt.Printf("Restricted to the diff: issues (%d)\n", len(parsedReport))
 	}
 
 	jsonReport, _ := model.ReportListToJSON(parsedReport)
@@ -142,5 +277,16 @@ func codeQualityCmdF(command *cobra.Command, args []string) error {
 		fmt.Printf("Report created at: %s\n", transformCommand.outputFile)
 	}
 
+	if violations := transformCommand.qualityGate.Evaluate(gatedReport); len(violations) > 0 {
+		fmt.Fprintln(os.Stderr, "Quality gate failed:")
+		for _, violation := range violations {
+			fmt.Fprintf(os.Stderr, "  - %s\n", violation)
+		}
+
+		// The failure is not a usage error
+		command.SilenceUsage = true
+		return errors.New("quality gate failed")
+	}
+
 	return nil
 }
@@ -1,11 +1,16 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/LOQ9/gitlab-reporter/model"
@@ -23,37 +28,259 @@ var CoverageCmd = &cobra.Command{
 }
 
 type CoverageCommand struct {
-	// sourceReport   string
+	inputs         []string
+	outputFile     string
+	summary        bool
 	byFiles        bool
 	ignoreGenFiles bool
 	ignoreDirs     string
 	ignoreFiles    string
+	threshold      model.CoverageThreshold
+	diffBase       string
+	diffFile       string
+	minDiff        float64
+	inputFormat    string
+	sourceRoot     string
+	crapThreshold  float64
+	crapOutputFile string
 }
 
-func NewCoverageCommand(flags *pflag.FlagSet) *CoverageCommand {
+func NewCoverageCommand(flags *pflag.FlagSet) (*CoverageCommand, error) {
 	coverageCommand := CoverageCommand{}
-	// coverageCommand.sourceReport, _ = flags.GetString("source-report")
+	coverageCommand.inputs, _ = flags.GetStringSlice("input")
+	sourceReports, _ := flags.GetStringSlice("source-report")
+	coverageCommand.inputs = append(coverageCommand.inputs, sourceReports...)
+	coverageCommand.outputFile, _ = flags.GetString("output-file")
+	coverageCommand.summary, _ = flags.GetBool("summary")
 	coverageCommand.byFiles, _ = flags.GetBool("by-files")
 	coverageCommand.ignoreGenFiles, _ = flags.GetBool("ignore-gen-files")
 	coverageCommand.ignoreDirs, _ = flags.GetString("ignore-dirs")
 	coverageCommand.ignoreFiles, _ = flags.GetString("ignore-files")
+	coverageCommand.threshold.MinTotal, _ = flags.GetFloat64("min-total")
+	coverageCommand.threshold.MinPackage, _ = flags.GetFloat64("min-package")
+	coverageCommand.threshold.MinFile, _ = flags.GetFloat64("min-file")
+	coverageCommand.diffBase, _ = flags.GetString("diff-base")
+	coverageCommand.diffFile, _ = flags.GetString("diff-file")
+	coverageCommand.minDiff, _ = flags.GetFloat64("min-diff")
+	coverageCommand.inputFormat, _ = flags.GetString("input-format")
+	coverageCommand.sourceRoot, _ = flags.GetString("source-root")
+	coverageCommand.crapThreshold, _ = flags.GetFloat64("crap-threshold")
+	coverageCommand.crapOutputFile, _ = flags.GetString("crap-output-file")
 
-	return &coverageCommand
+	packageOverrides, _ := flags.GetStringSlice("min-package-override")
+	for _, packageOverride := range packageOverrides {
+		packageThreshold, err := model.ParsePackageThreshold(packageOverride)
+		if err != nil {
+			return nil, err
+		}
+		coverageCommand.threshold.PackageOverrides = append(coverageCommand.threshold.PackageOverrides, packageThreshold)
+	}
+
+	return &coverageCommand, nil
+}
+
+// Inputs opens the coverage inputs, expanding globs, or returns stdin when none was specified.
+// The returned function closes the opened files.
+func (t *CoverageCommand) Inputs() ([]io.Reader, func(), error) {
+	if len(t.inputs) == 0 {
+		return []io.Reader{os.Stdin}, func() {}, nil
+	}
+
+	files := make([]*os.File, 0)
+	closeFiles := func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+
+	ins := make([]io.Reader, 0)
+	for _, input := range t.inputs {
+		matches, err := filepath.Glob(input)
+		if err != nil {
+			closeFiles()
+			return nil, nil, errors.Wrapf(err, "bad input pattern %s", input)
+		}
+		if len(matches) == 0 {
+			closeFiles()
+			return nil, nil, fmt.Errorf("no coverage input matches %s", input)
+		}
+
+		for _, match := range matches {
+			// Binary coverage data directories written to GOCOVERDIR are decoded into a text profile
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				var profile bytes.Buffer
+				if err := model.WriteCovDataProfile(match, &profile); err != nil {
+					closeFiles()
+					return nil, nil, errors.Wrapf(err, "could not read the coverage data directory %s", match)
+				}
+				ins = append(ins, &profile)
+				continue
+			}
+
+			f, err := os.Open(match)
+			if err != nil {
+				closeFiles()
+				return nil, nil, err
+			}
+			files = append(files, f)
+			ins = append(ins, f)
+		}
+	}
+
+	return ins, closeFiles, nil
+}
+
+// WriteOutput writes the Cobertura report to stdout, or to the output file through a temporary file
+// renamed once complete, so that a failure never leaves a truncated report behind
+func (t *CoverageCommand) WriteOutput(report []byte) error {
+	if t.outputFile == "" {
+		_, err := os.Stdout.Write(report)
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(t.outputFile), filepath.Base(t.outputFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(report); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), t.outputFile); err != nil {
+		_ = os.Remove(f.Name())
+		return errors.Wrap(err, "could not write the coverage report")
+	}
+
+	return nil
+}
+
+// PrintSummary prints the line and branch coverage, to stdout when the report is written to a file
+// and to stderr otherwise, so that the report on stdout remains valid
+func (t *CoverageCommand) PrintSummary(coverage *model.Coverage) {
+	if !t.summary {
+		return
+	}
+
+	out := os.Stderr
+	if t.outputFile != "" {
+		out = os.Stdout
+	}
+
+	_, _ = fmt.Fprintf(out, "Line coverage: %.2f%% (%d/%d lines), branch coverage: %.2f%% (%d/%d branches)\n",
+		100*coverage.LineRate, coverage.LinesCovered, coverage.LinesValid,
+		100*coverage.BranchRate, coverage.BranchesCovered, coverage.BranchesValid)
+}
+
+// CheckThreshold prints a table of the coverage threshold violations to out
+func (t *CoverageCommand) CheckThreshold(coverage *model.Coverage, out io.Writer) error {
+	violations := t.threshold.Check(coverage)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "TYPE\tNAME\tCOVERAGE\tTHRESHOLD")
+	for _, violation := range violations {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%.2f%%\t%.2f%%\n", violation.Kind, violation.Name, violation.Coverage, violation.Threshold)
+	}
+	_ = writer.Flush()
+
+	return fmt.Errorf("coverage is below the threshold for %d elements", len(violations))
+}
+
+// CheckDiff prints the coverage of the changed lines, and the uncovered ones per file, to out
+func (t *CoverageCommand) CheckDiff(coverage *model.Coverage, out io.Writer) error {
+	if t.diffBase == "" && t.diffFile == "" {
+		return nil
+	}
+
+	diff, err := readDiff(t.diffFile, t.diffBase)
+	if err != nil {
+		return err
+	}
+
+	diffCoverage := coverage.DiffCoverage(diff)
+	percentage := 100 * diffCoverage.HitRate()
+
+	_, _ = fmt.Fprintf(out, "Diff coverage: %.2f%% (%d/%d changed lines)\n", percentage, diffCoverage.NumLinesWithHits(), diffCoverage.NumLines())
+	for _, file := range diffCoverage.Files {
+		if len(file.Uncovered) > 0 {
+			_, _ = fmt.Fprintf(out, "  %s: %s\n", file.Filename, model.FormatLineRanges(file.Uncovered))
+		}
+	}
+
+	if float64(percentage) < t.minDiff {
+		return fmt.Errorf("diff coverage %.2f%% is below the threshold of %.2f%%", percentage, t.minDiff)
+	}
+
+	return nil
+}
+
+// CrapReport writes a code climate report of the functions whose CRAP score exceeds the threshold
+func (t *CoverageCommand) CrapReport(coverage *model.Coverage, out io.Writer) error {
+	if t.crapOutputFile == "" {
+		return nil
+	}
+
+	reports := coverage.CrapReports(t.crapThreshold, model.ReportTypeIssue)
+	model.ComputeFingerprints(reports)
+
+	jsonReport, err := model.ReportListToJSON(reports)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(t.crapOutputFile, jsonReport, 0644); err != nil {
+		return errors.Wrap(err, "could not write the CRAP report")
+	}
+
+	_, _ = fmt.Fprintf(out, "CRAP report created at: %s (%d functions above %.1f)\n", t.crapOutputFile, len(reports), t.crapThreshold)
+	return nil
 }
 
 func init() {
+	CoverageCmd.Flags().StringSlice("input", []string{}, "coverage profiles, GOCOVERDIR directories or globs to merge, e.g. from parallel jobs, read from stdin when empty")
+	CoverageCmd.Flags().StringSlice("source-report", []string{}, "same as --input")
+	CoverageCmd.Flags().String("output-file", "", "write the Cobertura report to this file instead of stdout")
+	CoverageCmd.Flags().Bool("summary", false, "print the line and branch coverage, to stdout when --output-file is set")
 	CoverageCmd.Flags().Bool("by-files", false, "code coverage by file, not class")
 	CoverageCmd.Flags().Bool("ignore-gen-files", false, "ignore generated files")
 	CoverageCmd.Flags().String("ignore-dirs", "", "ignore dirs matching this regexp")
 	CoverageCmd.Flags().String("ignore-files", "", "ignore files matching this regexp")
+	CoverageCmd.Flags().Float64("min-total", 0, "minimum total line coverage percentage")
+	CoverageCmd.Flags().Float64("min-package", 0, "minimum line coverage percentage of each package")
+	CoverageCmd.Flags().Float64("min-file", 0, "minimum line coverage percentage of each file")
+	CoverageCmd.Flags().StringSlice("min-package-override", []string{}, "minimum line coverage percentage of packages matching a glob, e.g. 'github.com/org/repo/internal/...=60'")
+	CoverageCmd.Flags().String("input-format", "", "coverage input format (go, lcov), detected from the content when empty")
+	CoverageCmd.Flags().String("source-root", "", "resolve packages from the go.mod or go.work file of this directory instead of the go toolchain")
+	CoverageCmd.Flags().String("diff-base", "", "compute the coverage of the lines changed between this git ref and HEAD")
+	CoverageCmd.Flags().String("diff-file", "", "compute the coverage of the lines changed by this unified diff file")
+	CoverageCmd.Flags().Float64("min-diff", 0, "minimum line coverage percentage of the changed lines")
+	CoverageCmd.Flags().Float64("crap-threshold", 30, "CRAP score above which a function is reported as a code quality issue")
+	CoverageCmd.Flags().String("crap-output-file", "", "write a code climate report of the functions above the CRAP threshold to this file")
 	RootCmd.AddCommand(CoverageCmd)
 }
 
 func coverageCmdF(command *cobra.Command, args []string) error {
-	coverageCommand := NewCoverageCommand(command.Flags())
+	coverageCommand, err := NewCoverageCommand(command.Flags())
+	if err != nil {
+		return err
+	}
 
-	var err error
-	var ignore model.Ignore
+	ignore := model.Ignore{GeneratedFiles: coverageCommand.ignoreGenFiles}
 	if coverageCommand.ignoreDirs != "" {
 		ignore.Dirs, err = regexp.Compile(coverageCommand.ignoreDirs)
 		if err != nil {
@@ -68,49 +295,185 @@ func coverageCmdF(command *cobra.Command, args []string) error {
 		}
 	}
 
-	if err := convert(os.Stdin, os.Stdout, &ignore); err != nil {
+	ins, closeInputs, err := coverageCommand.Inputs()
+	if err != nil {
+		return err
+	}
+	defer closeInputs()
+
+	var out bytes.Buffer
+	coverage, err := convert(ins, &out, &ignore, coverageCommand.inputFormat, coverageCommand.sourceRoot, coverageCommand.byFiles)
+	if err != nil {
 		return errors.Wrap(err, "code coverage conversion failed")
 	}
 
+	if err := coverageCommand.WriteOutput(out.Bytes()); err != nil {
+		return err
+	}
+
+	if coverageCommand.outputFile != "" {
+		fmt.Printf("Report created at: %s\n", coverageCommand.outputFile)
+	}
+
+	coverageCommand.PrintSummary(coverage)
+
+	// The failures below are not usage errors
+	command.SilenceUsage = true
+
+	if err := coverageCommand.CrapReport(coverage, os.Stderr); err != nil {
+		return err
+	}
+
+	thresholdErr := coverageCommand.CheckThreshold(coverage, os.Stderr)
+
+	if err := coverageCommand.CheckDiff(coverage, os.Stderr); err != nil {
+		return err
+	}
+
+	if thresholdErr != nil {
+		return thresholdErr
+	}
+
 	return nil
 }
 
-func convert(in io.Reader, out io.Writer, ignore *model.Ignore) error {
-	profiles, err := model.ParseProfiles(in, ignore)
+func convert(ins []io.Reader, out io.Writer, ignore *model.Ignore, inputFormat string, sourceRoot string, byFiles bool) (*model.Coverage, error) {
+	readers := make([]io.Reader, len(ins))
+	for idx, in := range ins {
+		readers[idx] = bufio.NewReader(in)
+	}
+
+	if inputFormat == "" {
+		var err error
+		inputFormat, err = detectCoverageFormat(readers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var coverage *model.Coverage
+	var err error
+	switch inputFormat {
+	case model.CoverageFormatGo:
+		coverage, err = convertProfiles(readers, ignore, sourceRoot, byFiles)
+	case model.CoverageFormatLCOV:
+		coverage, err = convertLCOV(readers, ignore, sourceRoot)
+	default:
+		err = fmt.Errorf("unknown coverage input format %q", inputFormat)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	pkgs, err := model.GetPackages(profiles)
+	_, _ = fmt.Fprint(out, xml.Header)
+	_, _ = fmt.Fprintln(out, model.CoberturaDTDDecl)
+
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(coverage); err != nil {
+		return nil, err
+	}
+
+	_, _ = fmt.Fprintln(out)
+	return coverage, nil
+}
+
+// detectCoverageFormat detects the format of every input, skipping the empty ones, and fails when they differ
+func detectCoverageFormat(readers []io.Reader) (string, error) {
+	inputFormat := ""
+	for idx, reader := range readers {
+		// Peek is limited by the buffer size, which is larger than any first line
+		head, _ := reader.(*bufio.Reader).Peek(512)
+		if len(bytes.TrimSpace(head)) == 0 {
+			continue
+		}
+
+		format := model.DetectCoverageFormat(head)
+		if inputFormat != "" && format != inputFormat {
+			return "", fmt.Errorf("coverage input %d is a %s report while the previous ones are %s reports", idx+1, format, inputFormat)
+		}
+		inputFormat = format
+	}
+
+	if inputFormat == "" {
+		return model.CoverageFormatGo, nil
+	}
+
+	return inputFormat, nil
+}
+
+func convertProfiles(ins []io.Reader, ignore *model.Ignore, sourceRoot string, byFiles bool) (*model.Coverage, error) {
+	profiles, err := model.MergeProfiles(ins, ignore)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	pkgs, err := getPackages(profiles, sourceRoot)
+	if err != nil {
+		return nil, err
 	}
 
-	sources := make([]*model.Source, 0)
 	pkgMap := make(map[string]*packages.Package)
 	for _, pkg := range pkgs {
 		if pkg.Module == nil {
 			continue
 		}
 
-		sources = model.AppendIfUnique(sources, pkg.Module.Dir)
 		pkgMap[pkg.ID] = pkg
 	}
 
-	coverage := model.Coverage{Sources: sources, Packages: nil, Timestamp: time.Now().UnixNano() / int64(time.Millisecond)}
-	if err := coverage.ParseProfiles(profiles, pkgMap, ignore); err != nil {
-		return err
+	// The sources are the directories of the modules of the parsed files
+	coverage := model.Coverage{Sources: []*model.Source{}, Packages: nil, Timestamp: time.Now().UnixNano() / int64(time.Millisecond)}
+	if err := coverage.ParseProfiles(profiles, pkgMap, ignore, byFiles); err != nil {
+		return nil, err
 	}
 
-	_, _ = fmt.Fprint(out, xml.Header)
-	_, _ = fmt.Fprintln(out, model.CoberturaDTDDecl)
+	return &coverage, nil
+}
 
-	encoder := xml.NewEncoder(out)
-	encoder.Indent("", "  ")
-	if err := encoder.Encode(coverage); err != nil {
-		return err
+// getPackages resolves the packages of the profiles with the go toolchain,
+// or from the modules of the source root when one is specified
+func getPackages(profiles []*model.Profile, sourceRoot string) ([]*packages.Package, error) {
+	if sourceRoot == "" {
+		return model.GetPackages(profiles)
 	}
 
-	_, _ = fmt.Fprintln(out)
-	return nil
+	modules, err := model.FindModules(sourceRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find the modules of the source root")
+	}
+
+	return model.ResolvePackages(profiles, modules)
+}
+
+func convertLCOV(ins []io.Reader, ignore *model.Ignore, sourceRoot string) (*model.Coverage, error) {
+	// Tracefiles are concatenated, the records of a file listed several times are merged
+	readers := make([]io.Reader, 0, 2*len(ins))
+	for _, in := range ins {
+		readers = append(readers, in, strings.NewReader("\n"))
+	}
+
+	files, err := model.ParseLCOV(io.MultiReader(readers...))
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceRoot == "" {
+		sourceRoot, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sourceRoot, err = filepath.Abs(sourceRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := model.Coverage{Sources: nil, Packages: nil, Timestamp: time.Now().UnixNano() / int64(time.Millisecond)}
+	if err := coverage.ParseLCOV(files, sourceRoot, ignore); err != nil {
+		return nil, err
+	}
+
+	return &coverage, nil
 }
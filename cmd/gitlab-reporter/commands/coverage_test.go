@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LOQ9/gitlab-reporter/model"
+	"github.com/spf13/pflag"
+)
+
+const goProfile = "mode: set\nexample.com/app/main.go:3.13,5.2 1 1\n"
+
+func TestNewCoverageCommandSourceReport(t *testing.T) {
+	flags := pflag.NewFlagSet("coverage", pflag.ContinueOnError)
+	flags.StringSlice("input", []string{}, "")
+	flags.StringSlice("source-report", []string{}, "")
+	if err := flags.Parse([]string{"--input", "a.out,b.out", "--source-report", "c.out"}); err != nil {
+		t.Fatal(err)
+	}
+
+	coverageCommand, err := NewCoverageCommand(flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(coverageCommand.inputs, ",") != "a.out,b.out,c.out" {
+		t.Errorf("unexpected inputs %v", coverageCommand.inputs)
+	}
+}
+
+func TestCoverageInputs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.out", "b.out", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// testdata/covdata is a GOCOVERDIR directory written by a program built with -covermode=count
+	coverageCommand := CoverageCommand{inputs: []string{filepath.Join(dir, "*.out"), "../../../model/testdata/covdata"}}
+	ins, closeInputs, err := coverageCommand.Inputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeInputs()
+
+	contents := make([]string, 0, len(ins))
+	for _, in := range ins {
+		content, err := io.ReadAll(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, string(content))
+	}
+
+	if len(contents) != 3 || contents[0] != "a.out" || contents[1] != "b.out" {
+		t.Fatalf("unexpected inputs %q", contents)
+	}
+	if !strings.HasPrefix(contents[2], "mode: count\nexample.com/covdata/main.go:") {
+		t.Errorf("the coverage data directory was not decoded, got %q", contents[2])
+	}
+
+	coverageCommand = CoverageCommand{inputs: []string{filepath.Join(dir, "*.out"), filepath.Join(dir, "*.info")}}
+	if _, _, err := coverageCommand.Inputs(); err == nil || !strings.Contains(err.Error(), "no coverage input matches") {
+		t.Errorf("expected an error for a pattern without match, got %v", err)
+	}
+}
+
+func TestDetectCoverageFormat(t *testing.T) {
+	lcov, err := os.ReadFile("../../../model/testdata/lcov.info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		inputs   []string
+		expected string
+		fails    bool
+	}{
+		{name: "go", inputs: []string{goProfile, goProfile}, expected: model.CoverageFormatGo},
+		{name: "empty first input", inputs: []string{"", "\n", string(lcov)}, expected: model.CoverageFormatLCOV},
+		{name: "empty inputs", inputs: []string{""}, expected: model.CoverageFormatGo},
+		{name: "mixed formats", inputs: []string{goProfile, string(lcov)}, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readers := make([]io.Reader, 0, len(tt.inputs))
+			for _, input := range tt.inputs {
+				readers = append(readers, bufio.NewReader(strings.NewReader(input)))
+			}
+
+			format, err := detectCoverageFormat(readers)
+			if tt.fails {
+				if err == nil {
+					t.Errorf("expected an error, got the %s format", format)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.expected {
+				t.Errorf("expected the %s format, got %s", tt.expected, format)
+			}
+		})
+	}
+}
+
+func TestConvertDetectsFormatOfAllInputs(t *testing.T) {
+	lcov, err := os.ReadFile("../../../model/testdata/lcov.info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ins := []io.Reader{strings.NewReader(""), bytes.NewReader(lcov)}
+	coverage, err := convert(ins, &out, &model.Ignore{}, "", "/home/user/project", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coverage.LinesValid == 0 || !strings.Contains(out.String(), "<coverage") {
+		t.Errorf("unexpected report %s", out.String())
+	}
+
+	out.Reset()
+	ins = []io.Reader{strings.NewReader(goProfile), bytes.NewReader(lcov)}
+	if _, err := convert(ins, &out, &model.Ignore{}, "", "", false); err == nil {
+		t.Error("expected an error for inputs of different formats")
+	}
+	if out.Len() > 0 {
+		t.Errorf("expected no output for a failed conversion, got %s", out.String())
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "coverage.xml")
+	if err := os.WriteFile(outputFile, []byte("previous"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	coverageCommand := CoverageCommand{outputFile: outputFile}
+	if err := coverageCommand.WriteOutput([]byte("<coverage/>")); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "<coverage/>" {
+		t.Errorf("unexpected report %q", content)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected the temporary file to be renamed, got %d files", len(entries))
+	}
+}
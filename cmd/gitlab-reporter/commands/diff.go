@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+
+	"github.com/LOQ9/gitlab-reporter/model"
+	"github.com/pkg/errors"
+)
+
+// readDiff parses the unified diff file, or the output of git diff between the base ref and HEAD
+func readDiff(diffFile string, diffBase string) (*model.Diff, error) {
+	if diffFile != "" {
+		f, err := os.Open(diffFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "specified diff file was not found")
+		}
+		defer f.Close()
+
+		return model.ParseUnifiedDiff(f)
+	}
+
+	var stderr bytes.Buffer
+	// The prefixes are set as the diff.noprefix and diff.mnemonicPrefix settings would change them
+	cmd := exec.Command("git", "diff", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/", "--unified=0", diffBase+"...HEAD")
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "git diff against %s failed: %s", diffBase, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return model.ParseUnifiedDiff(bytes.NewReader(out))
+}
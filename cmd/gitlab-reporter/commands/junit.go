@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/LOQ9/gitlab-reporter/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// JUnitCmd ...
+var JUnitCmd = &cobra.Command{
+	Use:   "junit",
+	Short: "JUnit test report from go test -json output",
+	RunE:  junitCmdF,
+}
+
+type JUnitCommand struct {
+	sourceReport []string
+	outputFile   string
+}
+
+func NewJUnitCommand(flags *pflag.FlagSet) *JUnitCommand {
+	junitCommand := JUnitCommand{}
+	junitCommand.sourceReport, _ = flags.GetStringSlice("source-report")
+	junitCommand.outputFile, _ = flags.GetString("output-file")
+
+	return &junitCommand
+}
+
+func init() {
+	JUnitCmd.Flags().StringSlice("source-report", []string{}, "go test -json output files, read from stdin when empty")
+	JUnitCmd.Flags().String("output-file", "", "Output File Name, written to stdout when empty")
+	RootCmd.AddCommand(JUnitCmd)
+}
+
+func junitCmdF(command *cobra.Command, args []string) error {
+	junitCommand := NewJUnitCommand(command.Flags())
+	converter := model.NewGoTestConverter()
+
+	if len(junitCommand.sourceReport) == 0 {
+		if err := converter.Parse(os.Stdin); err != nil {
+			return errors.Wrap(err, "could not read go test output")
+		}
+	}
+
+	for _, report := range junitCommand.sourceReport {
+		f, err := os.Open(report)
+		if err != nil {
+			return errors.New("specified source report was not found")
+		}
+
+		err = converter.Parse(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "could not read go test output from %s", report)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if junitCommand.outputFile != "" {
+		f, err := os.Create(junitCommand.outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	_, _ = fmt.Fprint(out, xml.Header)
+
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(converter.TestSuites()); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out)
+	return nil
+}
@@ -7,12 +7,12 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/spf13/cobra v1.3.0
 	github.com/spf13/pflag v1.0.5
+	golang.org/x/mod v0.5.0
 	golang.org/x/tools v0.1.5
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	golang.org/x/mod v0.5.0 // indirect
 	golang.org/x/sys v0.0.0-20211205182925-97ca703d548d // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 )
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+func init() {
+	RegisterParser(ReportFormatBandit, NewBanditParser)
+}
+
+// BanditResult represents the JSON output of bandit (-f json).
+// {"errors": [], "generated_at": "...", "metrics": {...}, "results": [{"test_id": "B602", "issue_severity": "HIGH", ...}]}
+//
+// References:
+// https://bandit.readthedocs.io/en/latest/formatters/json.html
+type BanditResult struct {
+	Results []*BanditIssue `json:"results"`
+}
+
+// BanditIssue represents an issue, columns are 0 based and the end column is exclusive
+type BanditIssue struct {
+	TestID          string     `json:"test_id"`
+	TestName        string     `json:"test_name"`
+	IssueText       string     `json:"issue_text"`
+	IssueSeverity   string     `json:"issue_severity"`
+	IssueConfidence string     `json:"issue_confidence"`
+	IssueCwe        *BanditCwe `json:"issue_cwe"`
+	Filename        string     `json:"filename"`
+	LineNumber      int        `json:"line_number"`
+	LineRange       []int      `json:"line_range"`
+	ColOffset       int        `json:"col_offset"`
+	EndColOffset    *int       `json:"end_col_offset"`
+	MoreInfo        string     `json:"more_info"`
+}
+
+// BanditCwe represents the CWE of an issue
+type BanditCwe struct {
+	ID   int    `json:"id"`
+	Link string `json:"link"`
+}
+
+// BanditParser reads bandit JSON reports
+type BanditParser struct {
+	options ParserOptions
+}
+
+// NewBanditParser creates a bandit JSON parser
+func NewBanditParser(options ParserOptions) Parser {
+	return &BanditParser{options: options}
+}
+
+func (p *BanditParser) Name() string {
+	return ReportFormatBandit
+}
+
+func (p *BanditParser) Detect(data []byte) bool {
+	// SARIF logs of bandit also list "results", within "runs"
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"results"`)) &&
+		!bytes.Contains(trimmedData, []byte(`"runs"`)) &&
+		(bytes.Contains(trimmedData, []byte(`"issue_severity"`)) || bytes.Contains(trimmedData, []byte(`"generated_at"`)))
+}
+
+func (p *BanditParser) Parse(in io.Reader) ([]*Report, error) {
+	var result BanditResult
+	if err := json.NewDecoder(in).Decode(&result); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a bandit json report")
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatBandit
+	}
+
+	reports := make([]*Report, 0, len(result.Results))
+	for _, issue := range result.Results {
+		reports = append(reports, NewReportFromBandit(issue, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+// NewReportFromBandit converts an issue into a Report
+func NewReportFromBandit(issue *BanditIssue, reportType string, reportEngine string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   issue.TestID,
+		Description: issue.IssueText,
+		Location: ReportLocation{
+			Path: fingerprintPath(issue.Filename),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   issue.LineNumber,
+					Column: issue.ColOffset + 1,
+				},
+				End: ReportLocationPositionsData{
+					Line:   issue.LineNumber,
+					Column: issue.ColOffset + 1,
+				},
+			},
+		},
+	}
+
+	if len(issue.LineRange) > 0 {
+		newReport.Location.Positions.End.Line = issue.LineRange[len(issue.LineRange)-1]
+	}
+
+	if issue.EndColOffset != nil {
+		newReport.Location.Positions.End.Column = *issue.EndColOffset + 1
+	}
+
+	cwes := make([]string, 0)
+	if issue.IssueCwe != nil {
+		cwes = append(cwes, strconv.Itoa(issue.IssueCwe.ID))
+	}
+	newReport.Content.Body = securityBody(cwes, []string{issue.MoreInfo})
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(securityFindingSeverity(issue.IssueSeverity, issue.IssueConfidence))
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	newReport.Categories = []string{Security}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
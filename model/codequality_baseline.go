@@ -0,0 +1,66 @@
+package model
+
+import (
+	"encoding/json"
+)
+
+// BaselineComparison classifies the issues of a report against a baseline report by fingerprint
+type BaselineComparison struct {
+	// New issues are only present in the current report
+	New []*Report
+	// Unchanged issues are present in both reports
+	Unchanged []*Report
+	// Fixed issues are only present in the baseline report
+	Fixed []*Report
+}
+
+// ReportListFromJSON reads a Code Climate JSON report, e.g. the artifact of a previous pipeline
+func ReportListFromJSON(data []byte) ([]*Report, error) {
+	reports := make([]*Report, 0)
+	if err := json.Unmarshal(data, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// CompareBaseline matches the current reports with the baseline reports by fingerprint.
+// Baseline reports without a fingerprint, e.g. produced by other tools, are fingerprinted first.
+func CompareBaseline(reports []*Report, baseline []*Report) *BaselineComparison {
+	comparison := &BaselineComparison{
+		New:       make([]*Report, 0),
+		Unchanged: make([]*Report, 0),
+		Fixed:     make([]*Report, 0),
+	}
+
+	missingFingerprint := make([]*Report, 0)
+	for _, r := range baseline {
+		if r.Fingerprint == "" {
+			missingFingerprint = append(missingFingerprint, r)
+		}
+	}
+	ComputeFingerprints(missingFingerprint)
+
+	baselineCount := make(map[string]int)
+	for _, r := range baseline {
+		baselineCount[r.Fingerprint]++
+	}
+
+	for _, r := range reports {
+		if baselineCount[r.Fingerprint] > 0 {
+			baselineCount[r.Fingerprint]--
+			comparison.Unchanged = append(comparison.Unchanged, r)
+			continue
+		}
+		comparison.New = append(comparison.New, r)
+	}
+
+	for _, r := range baseline {
+		if baselineCount[r.Fingerprint] > 0 {
+			baselineCount[r.Fingerprint]--
+			comparison.Fixed = append(comparison.Fixed, r)
+		}
+	}
+
+	return comparison
+}
@@ -1,6 +1,16 @@
 package model
 
-import "encoding/xml"
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+func init() {
+	RegisterParser(ReportFormatCheckstyle, NewCheckStyleParser)
+}
 
 // CheckStyleResult represents checkstyle XML result.
 // <?xml version="1.0" encoding="utf-8"?><checkstyle version="4.3"><file ...></file>...</checkstyle>
@@ -28,3 +38,44 @@ type CheckStyleError struct {
 	Severity string `xml:"severity,attr,omitempty"`
 	Source   string `xml:"source,attr,omitempty"`
 }
+
+// CheckStyleParser reads checkstyle XML reports
+type CheckStyleParser struct {
+	options ParserOptions
+}
+
+// NewCheckStyleParser creates a checkstyle parser
+func NewCheckStyleParser(options ParserOptions) Parser {
+	return &CheckStyleParser{options: options}
+}
+
+func (p *CheckStyleParser) Name() string {
+	return ReportFormatCheckstyle
+}
+
+func (p *CheckStyleParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("<")) && bytes.Contains(trimmedData, []byte("<checkstyle"))
+}
+
+func (p *CheckStyleParser) Parse(in io.Reader) ([]*Report, error) {
+	byteValue, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CheckStyleResult
+	if err := xml.Unmarshal(byteValue, &result); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be xml checkstyle compliant")
+	}
+
+	// Assemble Gitlab report compatible structure
+	reports := make([]*Report, 0)
+	for _, file := range result.Files {
+		for _, fileCheckStyleError := range file.Errors {
+			reports = append(reports, NewReportFromCheckstyle(fileCheckStyleError, p.options.ReportType, p.options.ReportEngine, file.Name))
+		}
+	}
+
+	return reports, nil
+}
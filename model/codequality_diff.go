@@ -0,0 +1,20 @@
+package model
+
+// FilterReportsByDiff keeps the reports whose location intersects the lines changed by the diff.
+// With wholeFiles every report of a changed file is kept.
+func FilterReportsByDiff(reports []*Report, diff *Diff, wholeFiles bool) []*Report {
+	filteredReports := make([]*Report, 0)
+
+	for _, r := range reports {
+		fileDiff := diff.File(r.Location.Path)
+		if fileDiff == nil {
+			continue
+		}
+
+		if wholeFiles || fileDiff.Intersects(r.Location.Positions.Begin.Line, r.Location.Positions.End.Line) {
+			filteredReports = append(filteredReports, r)
+		}
+	}
+
+	return filteredReports
+}
@@ -1,5 +1,22 @@
 package model
 
+import "strings"
+
+// eslintCheckName returns the rule without its plugin, e.g. no-unused-vars for @typescript-eslint/no-unused-vars
+func eslintCheckName(ruleID string) string {
+	ruleIDSplit := strings.Split(ruleID, "/")
+	return ruleIDSplit[len(ruleIDSplit)-1]
+}
+
+// eslintRuleCategory returns the category of a rule, with or without its plugin, or an empty string for unknown rules
+func eslintRuleCategory(ruleID string) string {
+	if category := eslintCategory[ruleID]; category != "" {
+		return category
+	}
+
+	return eslintCategory[eslintCheckName(ruleID)]
+}
+
 var eslintCategory = map[string]string{
 	"accessor-pairs":                "Bug Risk",
 	"array-bracket-spacing":         "Clarity",
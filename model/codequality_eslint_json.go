@@ -0,0 +1,170 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatEslint, NewEslintParser)
+}
+
+// EslintResult represents the results of a file in the JSON output of ESLint (--format json).
+// [{"filePath": "/src/index.ts", "messages": [...], "suppressedMessages": [...], ...}]
+//
+// References:
+// https://eslint.org/docs/latest/use/formatters/#json
+// https://eslint.org/docs/latest/integrate/nodejs-api#-lintresult-type
+type EslintResult struct {
+	FilePath           string           `json:"filePath"`
+	Messages           []*EslintMessage `json:"messages"`
+	SuppressedMessages []*EslintMessage `json:"suppressedMessages,omitempty"`
+}
+
+// EslintMessage represents a problem found in a file, the rule is empty for fatal parsing errors
+type EslintMessage struct {
+	RuleID      *string             `json:"ruleId"`
+	Severity    int                 `json:"severity"`
+	Message     string              `json:"message"`
+	Line        int                 `json:"line"`
+	Column      int                 `json:"column"`
+	EndLine     int                 `json:"endLine,omitempty"`
+	EndColumn   int                 `json:"endColumn,omitempty"`
+	Fatal       bool                `json:"fatal,omitempty"`
+	Fix         *EslintFix          `json:"fix,omitempty"`
+	Suggestions []*EslintSuggestion `json:"suggestions,omitempty"`
+}
+
+// EslintFix represents the edit applied by eslint --fix
+type EslintFix struct {
+	Range []int  `json:"range"`
+	Text  string `json:"text"`
+}
+
+// EslintSuggestion represents an edit offered by editors, not applied by eslint --fix
+type EslintSuggestion struct {
+	Desc string     `json:"desc"`
+	Fix  *EslintFix `json:"fix"`
+}
+
+// EslintParser reads ESLint JSON reports
+type EslintParser struct {
+	options ParserOptions
+}
+
+// NewEslintParser creates an ESLint JSON parser
+func NewEslintParser(options ParserOptions) Parser {
+	return &EslintParser{options: options}
+}
+
+func (p *EslintParser) Name() string {
+	return ReportFormatEslint
+}
+
+func (p *EslintParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("[")) && bytes.Contains(trimmedData, []byte(`"filePath"`)) &&
+		bytes.Contains(trimmedData, []byte(`"messages"`))
+}
+
+func (p *EslintParser) Parse(in io.Reader) ([]*Report, error) {
+	var results []*EslintResult
+	if err := json.NewDecoder(in).Decode(&results); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be an eslint json report")
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportEngineEslint
+	}
+
+	// Suppressed messages are left out, they were disabled by comments in the source
+	reports := make([]*Report, 0)
+	for _, result := range results {
+		for _, message := range result.Messages {
+			reports = append(reports, NewReportFromEslint(message, p.options.ReportType, engine, result.FilePath))
+		}
+	}
+
+	return reports, nil
+}
+
+// NewReportFromEslint converts a message into a Report, the file path is made relative to the working directory
+func NewReportFromEslint(message *EslintMessage, reportType string, reportEngine string, filePath string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   eslintCheckName(message.ruleID()),
+		Description: message.Message,
+		Location: ReportLocation{
+			Path: fingerprintPath(filePath),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   message.Line,
+					Column: message.Column,
+				},
+				End: ReportLocationPositionsData{
+					Line:   message.EndLine,
+					Column: message.EndColumn,
+				},
+			},
+		},
+		Content: ReportContent{
+			Body: message.body(),
+		},
+	}
+
+	// Messages without an end, e.g. parsing errors, span their start position
+	if newReport.Location.Positions.End.Line == 0 {
+		newReport.Location.Positions.End = newReport.Location.Positions.Begin
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(message.severity())
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	// The rules are known whatever the engine, which may be set with --reporter-tool
+	if category := eslintRuleCategory(message.ruleID()); category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
+
+func (message *EslintMessage) ruleID() string {
+	if message.RuleID == nil {
+		return ""
+	}
+
+	return *message.RuleID
+}
+
+// severity maps the ESLint severity, 1 for warnings and 2 for errors
+func (message *EslintMessage) severity() string {
+	if message.Fatal || message.Severity == 2 {
+		return "error"
+	}
+
+	return "warning"
+}
+
+// body tells whether the problem is fixed by eslint --fix and lists the suggested fixes
+func (message *EslintMessage) body() string {
+	lines := make([]string, 0)
+
+	if message.Fix != nil {
+		lines = append(lines, "Fixable with `eslint --fix`")
+	}
+
+	for _, suggestion := range message.Suggestions {
+		lines = append(lines, "Suggestion: "+suggestion.Desc)
+	}
+
+	return strings.Join(lines, "\n")
+}
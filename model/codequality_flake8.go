@@ -0,0 +1,132 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+func init() {
+	RegisterParser(ReportFormatFlake8, NewFlake8Parser)
+}
+
+// Lines of the default and of the pylint output formats of flake8.
+// app.py:3:1: F401 'os' imported but unused
+// app.py:3: [F401] 'os' imported but unused
+//
+// References:
+// https://flake8.pycqa.org/en/latest/user/options.html#cmdoption-flake8-format
+var (
+	flake8DefaultRe = regexp.MustCompile(`^(.+?):(\d+):(\d+): ([A-Z]+[0-9]+) (.*)$`)
+	flake8PylintRe  = regexp.MustCompile(`^(.+?):(\d+): \[([A-Z]+[0-9]+)\] (.*)$`)
+)
+
+// Flake8Error represents an error reported by flake8, the column is 0 in the pylint format
+type Flake8Error struct {
+	Path    string
+	Line    int
+	Column  int
+	Code    string
+	Message string
+}
+
+// parseFlake8Line returns the error of a line of the flake8 output, if any
+func parseFlake8Line(line string) *Flake8Error {
+	if m := flake8DefaultRe.FindStringSubmatch(line); m != nil {
+		lineNumber, _ := strconv.Atoi(m[2])
+		column, _ := strconv.Atoi(m[3])
+		return &Flake8Error{Path: m[1], Line: lineNumber, Column: column, Code: m[4], Message: m[5]}
+	}
+
+	if m := flake8PylintRe.FindStringSubmatch(line); m != nil {
+		lineNumber, _ := strconv.Atoi(m[2])
+		return &Flake8Error{Path: m[1], Line: lineNumber, Code: m[3], Message: m[4]}
+	}
+
+	return nil
+}
+
+// Flake8Parser reads flake8 text reports
+type Flake8Parser struct {
+	options ParserOptions
+}
+
+// NewFlake8Parser creates a flake8 parser
+func NewFlake8Parser(options ParserOptions) Parser {
+	return &Flake8Parser{options: options}
+}
+
+func (p *Flake8Parser) Name() string {
+	return ReportFormatFlake8
+}
+
+func (p *Flake8Parser) Detect(data []byte) bool {
+	firstLine, _, _ := bytes.Cut(bytes.TrimSpace(data), []byte("\n"))
+	// The text output of ruff uses the same format
+	return parseFlake8Line(string(bytes.TrimSpace(firstLine))) != nil && !isRuffText(data)
+}
+
+func (p *Flake8Parser) Parse(in io.Reader) ([]*Report, error) {
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatFlake8
+	}
+
+	// Lines other than errors, e.g. of --statistics or --count, are skipped
+	reports := make([]*Report, 0)
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		if flake8Error := parseFlake8Line(string(bytes.TrimSpace(s.Bytes()))); flake8Error != nil {
+			reports = append(reports, NewReportFromFlake8(flake8Error, p.options.ReportType, engine))
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a flake8 report")
+	}
+
+	return reports, nil
+}
+
+// NewReportFromFlake8 converts an error into a Report
+func NewReportFromFlake8(flake8Error *Flake8Error, reportType string, reportEngine string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   flake8Error.Code,
+		Description: flake8Error.Message,
+		Location: ReportLocation{
+			Path: flake8Error.Path,
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   flake8Error.Line,
+					Column: flake8Error.Column,
+				},
+				End: ReportLocationPositionsData{
+					Line:   flake8Error.Line,
+					Column: flake8Error.Column,
+				},
+			},
+		},
+	}
+
+	severity := pythonCodeLookup(pythonCodeSeverity, flake8Error.Code)
+	if severity == "" {
+		severity = SeverityMinor
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(severity)
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	if category := pythonCodeLookup(pythonCodeCategory, flake8Error.Code); category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var severityLevel = map[string]int{
+	SeverityInfo:     0,
+	SeverityMinor:    1,
+	SeverityMajor:    2,
+	SeverityCritical: 3,
+	SeverityBlocker:  4,
+}
+
+var reportCategories = []string{BugRisk, Clarity, Compatibility, Complexity, Security, Style}
+
+// QualityGate holds the thresholds a list of reports must respect
+type QualityGate struct {
+	// FailOnSeverity fails the gate when an issue has this severity or a higher one
+	FailOnSeverity string
+	// MaxIssues fails the gate when there are more issues, a negative value disables it
+	MaxIssues int
+	// MaxCategoryIssues fails the gate when a category has more issues
+	MaxCategoryIssues map[string]int
+}
+
+// NewQualityGate validates the thresholds and creates a quality gate
+func NewQualityGate(failOnSeverity string, maxIssues int, maxCategoryIssues map[string]int) (*QualityGate, error) {
+	gate := &QualityGate{
+		FailOnSeverity:    strings.ToLower(failOnSeverity),
+		MaxIssues:         maxIssues,
+		MaxCategoryIssues: make(map[string]int),
+	}
+
+	if _, exists := severityLevel[gate.FailOnSeverity]; gate.FailOnSeverity != "" && !exists {
+		return nil, fmt.Errorf("unknown severity %q, must be one of info, minor, major, critical or blocker", failOnSeverity)
+	}
+
+	for categoryName, maxIssues := range maxCategoryIssues {
+		category := findCategory(categoryName)
+		if category == "" {
+			return nil, fmt.Errorf("unknown category %q, must be one of %s", categoryName, strings.Join(reportCategories, ", "))
+		}
+		gate.MaxCategoryIssues[category] = maxIssues
+	}
+
+	return gate, nil
+}
+
+// findCategory matches a category name ignoring case, spaces, dashes and underscores
+func findCategory(name string) string {
+	normalize := strings.NewReplacer(" ", "", "-", "", "_", "")
+	normalizedName := strings.ToLower(normalize.Replace(name))
+
+	for _, category := range reportCategories {
+		if strings.ToLower(normalize.Replace(category)) == normalizedName {
+			return category
+		}
+	}
+
+	return ""
+}
+
+// Evaluate returns a description of every threshold violated by the reports
+func (g *QualityGate) Evaluate(reports []*Report) []string {
+	violations := make([]string, 0)
+
+	if g.MaxIssues >= 0 && len(reports) > g.MaxIssues {
+		violations = append(violations, fmt.Sprintf("found %d issues, the maximum allowed is %d", len(reports), g.MaxIssues))
+	}
+
+	if g.FailOnSeverity != "" {
+		severityIssues := 0
+		for _, r := range reports {
+			if level, exists := severityLevel[r.Severity]; exists && level >= severityLevel[g.FailOnSeverity] {
+				severityIssues++
+			}
+		}
+
+		if severityIssues > 0 {
+			violations = append(violations, fmt.Sprintf("found %d issues with severity %s or higher", severityIssues, g.FailOnSeverity))
+		}
+	}
+
+	categoryIssues := make(map[string]int)
+	for _, r := range reports {
+		for _, category := range r.Categories {
+			categoryIssues[category]++
+		}
+	}
+
+	categories := make([]string, 0, len(g.MaxCategoryIssues))
+	for category := range g.MaxCategoryIssues {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		if categoryIssues[category] > g.MaxCategoryIssues[category] {
+			violations = append(violations, fmt.Sprintf("found %d %s issues, the maximum allowed is %d", categoryIssues[category], category, g.MaxCategoryIssues[category]))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatGolangci, NewGolangciParser)
+}
+
+// GolangciResult represents the JSON output of golangci-lint (--out-format json).
+// {"Issues": [{"FromLinter": "errcheck", "Text": "...", "Pos": {...}, ...}], "Report": {...}}
+//
+// References:
+// https://golangci-lint.run/usage/configuration/#output-configuration
+// https://github.com/golangci/golangci-lint/blob/master/pkg/result/issue.go
+type GolangciResult struct {
+	Issues []*GolangciIssue `json:"Issues"`
+}
+
+// GolangciIssue represents an issue reported by one of the linters
+type GolangciIssue struct {
+	FromLinter  string               `json:"FromLinter"`
+	Text        string               `json:"Text"`
+	Severity    string               `json:"Severity"`
+	SourceLines []string             `json:"SourceLines"`
+	Replacement *GolangciReplacement `json:"Replacement"`
+	Pos         GolangciPosition     `json:"Pos"`
+	LineRange   *GolangciLineRange   `json:"LineRange"`
+}
+
+// GolangciReplacement represents the fix suggested for an issue, either whole lines or an inline edit
+type GolangciReplacement struct {
+	NeedOnlyDelete bool                       `json:"NeedOnlyDelete"`
+	NewLines       []string                   `json:"NewLines"`
+	Inline         *GolangciInlineReplacement `json:"Inline"`
+}
+
+// GolangciInlineReplacement represents the replacement of Length bytes of the line at the 0 based StartCol
+type GolangciInlineReplacement struct {
+	StartCol  int    `json:"StartCol"`
+	Length    int    `json:"Length"`
+	NewString string `json:"NewString"`
+}
+
+// GolangciPosition represents the position of an issue
+type GolangciPosition struct {
+	Filename string `json:"Filename"`
+	Line     int    `json:"Line"`
+	Column   int    `json:"Column"`
+}
+
+// GolangciLineRange represents the lines spanned by an issue
+type GolangciLineRange struct {
+	From int `json:"From"`
+	To   int `json:"To"`
+}
+
+// GolangciParser reads golangci-lint JSON reports
+type GolangciParser struct {
+	options ParserOptions
+}
+
+// NewGolangciParser creates a golangci-lint JSON parser
+func NewGolangciParser(options ParserOptions) Parser {
+	return &GolangciParser{options: options}
+}
+
+func (p *GolangciParser) Name() string {
+	return ReportFormatGolangci
+}
+
+func (p *GolangciParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"Issues"`)) &&
+		(bytes.Contains(trimmedData, []byte(`"FromLinter"`)) || bytes.Contains(trimmedData, []byte(`"Report"`)))
+}
+
+func (p *GolangciParser) Parse(in io.Reader) ([]*Report, error) {
+	var result GolangciResult
+	if err := json.NewDecoder(in).Decode(&result); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a golangci-lint json report")
+	}
+
+	reports := make([]*Report, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		reports = append(reports, NewReportFromGolangci(issue, p.options.ReportType, p.options.ReportEngine))
+	}
+
+	return reports, nil
+}
+
+// NewReportFromGolangci converts an issue into a Report using the linter that reported it as engine.
+// The reportEngine is only used when the issue has no linter.
+func NewReportFromGolangci(issue *GolangciIssue, reportType string, reportEngine string) *Report {
+	engine := issue.FromLinter
+	if engine == "" {
+		engine = reportEngine
+	}
+
+	// The rule prefixed to the text, e.g. SA1019 for staticcheck, identifies the check better than the linter
+	checkName := golangciRule(issue.FromLinter, issue.Text)
+	if checkName == "" {
+		checkName = issue.FromLinter
+	}
+
+	newReport := &Report{
+		EngineName:  engine,
+		Type:        reportType,
+		CheckName:   checkName,
+		Description: issue.Text,
+		Location: ReportLocation{
+			Path:      issue.Pos.Filename,
+			Positions: issue.positions(),
+		},
+		Content: ReportContent{
+			Body: issue.body(),
+		},
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(golangciSeverity(issue.Severity))
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	if category := golangciCategory(issue.FromLinter, issue.Text); category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
+
+// Severities configured in golangci-lint, either the ones of the linters or the code climate ones
+var golangciSeverityMap = map[string]string{
+	"info":     SeverityInfo,
+	"low":      SeverityMinor,
+	"minor":    SeverityMinor,
+	"warning":  SeverityMinor,
+	"medium":   SeverityMajor,
+	"major":    SeverityMajor,
+	"error":    SeverityMajor,
+	"high":     SeverityCritical,
+	"critical": SeverityCritical,
+	"blocker":  SeverityBlocker,
+}
+
+// golangciSeverity maps the severity of an issue, golangci-lint reports issues without a configured
+// or with an unknown severity as errors
+func golangciSeverity(severity string) string {
+	if reportSeverity, exists := golangciSeverityMap[strings.ToLower(severity)]; exists {
+		return reportSeverity
+	}
+
+	return SeverityMajor
+}
+
+// positions returns the range of the issue, an inline replacement tells the exact columns
+func (issue *GolangciIssue) positions() ReportLocationPositions {
+	positions := ReportLocationPositions{
+		Begin: ReportLocationPositionsData{Line: issue.Pos.Line, Column: issue.Pos.Column},
+		End:   ReportLocationPositionsData{Line: issue.Pos.Line, Column: issue.Pos.Column},
+	}
+
+	if issue.LineRange != nil && issue.LineRange.To > issue.Pos.Line {
+		positions.End.Line = issue.LineRange.To
+	}
+
+	if issue.Replacement != nil && issue.Replacement.Inline != nil && positions.End.Line == positions.Begin.Line {
+		inline := issue.Replacement.Inline
+		positions.Begin.Column = inline.StartCol + 1
+		positions.End.Column = inline.StartCol + inline.Length + 1
+	}
+
+	return positions
+}
+
+// body shows the offending source lines and the suggested replacement, if any
+func (issue *GolangciIssue) body() string {
+	var body strings.Builder
+
+	if len(issue.SourceLines) > 0 {
+		body.WriteString("```go\n" + strings.Join(issue.SourceLines, "\n") + "\n```")
+	}
+
+	replacement := issue.Replacement
+	if replacement == nil {
+		return body.String()
+	}
+
+	if body.Len() > 0 {
+		body.WriteString("\n\n")
+	}
+
+	switch {
+	case replacement.NeedOnlyDelete:
+		body.WriteString("Suggested fix: delete the lines")
+	case replacement.Inline != nil:
+		body.WriteString("Suggested fix: replace with `" + replacement.Inline.NewString + "`")
+	default:
+		body.WriteString("Suggested fix:\n```go\n" + strings.Join(replacement.NewLines, "\n") + "\n```")
+	}
+
+	return body.String()
+}
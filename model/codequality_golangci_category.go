@@ -0,0 +1,229 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// golangciRuleRe matches the rule prefixed to the text of an issue by some linters,
+// e.g. "SA1019: ..." (staticcheck), "G104: ..." (gosec), "exported: ..." (revive) or "printf: ..." (govet)
+var golangciRuleRe = regexp.MustCompile(`^([A-Z]+[0-9]+|[a-z][a-z0-9-]*):\s`)
+
+// golangciCategory returns the category of an issue reported by a golangci-lint linter, the rule
+// prefixed to its text wins over the linter. An empty string is returned for unknown linters.
+func golangciCategory(linter string, text string) string {
+	if rule := golangciRule(linter, text); rule != "" {
+		if category := golangciRuleCategory[rule]; category != "" {
+			return category
+		}
+
+		// Families of staticcheck checks, e.g. SA for static analysis and ST for style
+		for _, prefix := range []string{"QF", "SA", "ST", "S", "G"} {
+			if strings.HasPrefix(rule, prefix) && strings.Trim(rule[len(prefix):], "0123456789") == "" {
+				return golangciRuleCategory[prefix]
+			}
+		}
+	}
+
+	return golangciLinterCategory[linter]
+}
+
+// golangciRule returns the rule prefixed to the text of an issue, or an empty string when there is none.
+// Named rules are only known for revive and govet, other linters may start a text with any word.
+func golangciRule(linter string, text string) string {
+	m := golangciRuleRe.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+
+	rule := m[1]
+	if strings.ToLower(rule) == rule && linter != "revive" && linter != "govet" {
+		return ""
+	}
+
+	return rule
+}
+
+var golangciLinterCategory = map[string]string{
+	"asasalint":         BugRisk,
+	"asciicheck":        BugRisk,
+	"bidichk":           Security,
+	"bodyclose":         BugRisk,
+	"containedctx":      Clarity,
+	"contextcheck":      BugRisk,
+	"cyclop":            Complexity,
+	"deadcode":          Clarity,
+	"depguard":          Style,
+	"dogsled":           Clarity,
+	"dupl":              Clarity,
+	"dupword":           Clarity,
+	"durationcheck":     BugRisk,
+	"errcheck":          BugRisk,
+	"errchkjson":        BugRisk,
+	"errname":           Style,
+	"errorlint":         BugRisk,
+	"execinquery":       BugRisk,
+	"exhaustive":        BugRisk,
+	"exhaustruct":       Style,
+	"exportloopref":     BugRisk,
+	"forcetypeassert":   BugRisk,
+	"funlen":            Complexity,
+	"gci":               Style,
+	"gochecknoglobals":  Clarity,
+	"gochecknoinits":    Clarity,
+	"gocognit":          Complexity,
+	"goconst":           Clarity,
+	"gocritic":          Style,
+	"gocyclo":           Complexity,
+	"godot":             Style,
+	"godox":             Clarity,
+	"goerr113":          Style,
+	"gofmt":             Style,
+	"gofumpt":           Style,
+	"goimports":         Style,
+	"gomnd":             Clarity,
+	"gomodguard":        Style,
+	"goprintffuncname":  Style,
+	"gosec":             Security,
+	"gosimple":          Clarity,
+	"gosmopolitan":      Compatibility,
+	"govet":             BugRisk,
+	"ineffassign":       BugRisk,
+	"interfacebloat":    Complexity,
+	"lll":               Style,
+	"loggercheck":       BugRisk,
+	"maintidx":          Complexity,
+	"makezero":          BugRisk,
+	"misspell":          Style,
+	"musttag":           BugRisk,
+	"nakedret":          Clarity,
+	"nestif":            Complexity,
+	"nilerr":            BugRisk,
+	"nilnil":            BugRisk,
+	"noctx":             BugRisk,
+	"nolintlint":        Style,
+	"nonamedreturns":    Style,
+	"nosprintfhostport": BugRisk,
+	"paralleltest":      Style,
+	"prealloc":          Clarity,
+	"predeclared":       BugRisk,
+	"promlinter":        Style,
+	"reassign":          BugRisk,
+	"revive":            Style,
+	"rowserrcheck":      BugRisk,
+	"sqlclosecheck":     BugRisk,
+	"staticcheck":       BugRisk,
+	"structcheck":       Clarity,
+	"stylecheck":        Style,
+	"tenv":              Style,
+	"testpackage":       Style,
+	"thelper":           Style,
+	"tparallel":         BugRisk,
+	"typecheck":         BugRisk,
+	"unconvert":         Clarity,
+	"unparam":           Clarity,
+	"unused":            Clarity,
+	"usestdlibvars":     Clarity,
+	"varcheck":          Clarity,
+	"varnamelen":        Clarity,
+	"wastedassign":      Clarity,
+	"whitespace":        Style,
+	"wrapcheck":         Style,
+}
+
+var golangciRuleCategory = map[string]string{
+	// staticcheck, gosimple and stylecheck families
+	"QF": Clarity,
+	"S":  Clarity,
+	"SA": BugRisk,
+	"ST": Style,
+	// gosec rules, see https://github.com/securego/gosec#available-rules
+	"G": Security,
+
+	"SA1019": Compatibility,
+	"SA4006": Clarity,
+	"SA4009": Clarity,
+	"SA4010": Clarity,
+	"SA5008": Compatibility,
+	"SA6000": Complexity,
+	"SA6001": Complexity,
+	"SA6002": Complexity,
+	"SA6003": Complexity,
+	"SA6005": Complexity,
+	"SA9003": Clarity,
+	"SA9004": Clarity,
+	"ST1000": Clarity,
+	"ST1003": Style,
+	"ST1020": Clarity,
+	"ST1021": Clarity,
+	"ST1022": Clarity,
+
+	// revive rules, see https://github.com/mgechev/revive#available-rules
+	"argument-limit":          Complexity,
+	"atomic":                  BugRisk,
+	"bare-return":             Clarity,
+	"blank-imports":           Style,
+	"bool-literal-in-expr":    Clarity,
+	"call-to-gc":              BugRisk,
+	"cognitive-complexity":    Complexity,
+	"confusing-naming":        Clarity,
+	"confusing-results":       Clarity,
+	"constant-logical-expr":   BugRisk,
+	"context-as-argument":     Style,
+	"context-keys-type":       BugRisk,
+	"cyclomatic":              Complexity,
+	"datarace":                BugRisk,
+	"deep-exit":               BugRisk,
+	"defer":                   BugRisk,
+	"dot-imports":             Style,
+	"duplicated-imports":      Clarity,
+	"early-return":            Clarity,
+	"empty-block":             Clarity,
+	"empty-lines":             Style,
+	"error-naming":            Style,
+	"error-return":            Style,
+	"error-strings":           Style,
+	"errorf":                  Style,
+	"exported":                Style,
+	"flag-parameter":          Clarity,
+	"function-length":         Complexity,
+	"function-result-limit":   Complexity,
+	"get-return":              Clarity,
+	"identical-branches":      BugRisk,
+	"if-return":               Clarity,
+	"increment-decrement":     Style,
+	"indent-error-flow":       Clarity,
+	"line-length-limit":       Style,
+	"max-control-nesting":     Complexity,
+	"max-public-structs":      Complexity,
+	"modifies-parameter":      BugRisk,
+	"modifies-value-receiver": BugRisk,
+	"package-comments":        Style,
+	"range":                   Clarity,
+	"range-val-address":       BugRisk,
+	"range-val-in-closure":    BugRisk,
+	"receiver-naming":         Style,
+	"redefines-builtin-id":    BugRisk,
+	"string-of-int":           BugRisk,
+	"superfluous-else":        Clarity,
+	"time-equal":              BugRisk,
+	"time-naming":             Style,
+	"unconditional-recursion": BugRisk,
+	"unexported-naming":       Style,
+	"unexported-return":       Style,
+	"unhandled-error":         BugRisk,
+	"unnecessary-stmt":        Clarity,
+	"unreachable-code":        BugRisk,
+	"unused-parameter":        Clarity,
+	"unused-receiver":         Clarity,
+	"use-any":                 Compatibility,
+	"useless-break":           Clarity,
+	"var-declaration":         Clarity,
+	"var-naming":              Style,
+	"waitgroup-by-value":      BugRisk,
+
+	// govet analyzers, see https://pkg.go.dev/golang.org/x/tools/go/analysis/passes
+	"composites":     Clarity,
+	"fieldalignment": Complexity,
+	"shadow":         BugRisk,
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatGosec, NewGosecParser)
+}
+
+// GosecResult represents the JSON output of gosec (-fmt=json).
+// {"Golang errors": {}, "Issues": [{"rule_id": "G304", "severity": "MEDIUM", "confidence": "HIGH", ...}], "Stats": {...}}
+//
+// References:
+// https://github.com/securego/gosec#output-formats
+type GosecResult struct {
+	Issues []*GosecIssue `json:"Issues"`
+}
+
+// GosecIssue represents an issue, its line is either a number or a range such as "12-14"
+type GosecIssue struct {
+	Severity     string              `json:"severity"`
+	Confidence   string              `json:"confidence"`
+	Cwe          *GosecCwe           `json:"cwe"`
+	RuleID       string              `json:"rule_id"`
+	Details      string              `json:"details"`
+	File         string              `json:"file"`
+	Line         string              `json:"line"`
+	Column       string              `json:"column"`
+	Nosec        bool                `json:"nosec"`
+	Suppressions []*GosecSuppression `json:"suppressions"`
+}
+
+// GosecCwe represents the CWE of an issue
+type GosecCwe struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// GosecSuppression represents a #nosec comment or an exclusion of the issue
+type GosecSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+// GosecParser reads gosec JSON reports
+type GosecParser struct {
+	options ParserOptions
+}
+
+// NewGosecParser creates a gosec JSON parser
+func NewGosecParser(options ParserOptions) Parser {
+	return &GosecParser{options: options}
+}
+
+func (p *GosecParser) Name() string {
+	return ReportFormatGosec
+}
+
+func (p *GosecParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"Issues"`)) &&
+		(bytes.Contains(trimmedData, []byte(`"Golang errors"`)) || bytes.Contains(trimmedData, []byte(`"rule_id"`)))
+}
+
+func (p *GosecParser) Parse(in io.Reader) ([]*Report, error) {
+	var result GosecResult
+	if err := json.NewDecoder(in).Decode(&result); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a gosec json report")
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatGosec
+	}
+
+	// Issues silenced by #nosec are only listed with -show-ignored
+	reports := make([]*Report, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		if issue.Nosec || len(issue.Suppressions) > 0 {
+			continue
+		}
+
+		reports = append(reports, NewReportFromGosec(issue, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+// NewReportFromGosec converts an issue into a Report, the file path is made relative to the working directory
+func NewReportFromGosec(issue *GosecIssue, reportType string, reportEngine string) *Report {
+	beginLine, endLine, _ := strings.Cut(issue.Line, "-")
+	if endLine == "" {
+		endLine = beginLine
+	}
+
+	column, _ := strconv.Atoi(issue.Column)
+
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   issue.RuleID,
+		Description: issue.Details,
+		Location: ReportLocation{
+			Path: fingerprintPath(issue.File),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Column: column,
+				},
+				End: ReportLocationPositionsData{
+					Column: column,
+				},
+			},
+		},
+	}
+
+	newReport.Location.Positions.Begin.Line, _ = strconv.Atoi(beginLine)
+	newReport.Location.Positions.End.Line, _ = strconv.Atoi(endLine)
+
+	if issue.Cwe != nil {
+		newReport.Content.Body = securityBody([]string{issue.Cwe.ID}, nil)
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(securityFindingSeverity(issue.Severity, issue.Confidence))
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	newReport.Categories = []string{Security}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
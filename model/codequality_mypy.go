@@ -0,0 +1,199 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+func init() {
+	RegisterParser(ReportFormatMypy, NewMypyParser)
+}
+
+// Lines of the text output of mypy, with --show-column-numbers and --show-error-end the columns and end
+// are added. Notes following an error give hints about it.
+// app.py:12: error: Incompatible return value type (got "int", expected "str")  [return-value]
+// app.py:12:5:12:9: error: Incompatible return value type (got "int", expected "str")  [return-value]
+//
+// References:
+// https://mypy.readthedocs.io/en/stable/command_line.html#configuring-error-messages
+var mypyLineRe = regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):)?(?:(\d+):(\d+):)? (error|warning|note): (.*?)(?:  \[([a-z][a-z0-9-]*)\])?$`)
+
+// MypyError represents an error of the JSON output of mypy (--output json), one object per line.
+// Columns are 0 based, or -1 when unknown.
+// {"file": "app.py", "line": 12, "column": 4, "message": "...", "hint": null, "code": "return-value", "severity": "error"}
+type MypyError struct {
+	File      string  `json:"file"`
+	Line      int     `json:"line"`
+	Column    int     `json:"column"`
+	EndLine   int     `json:"-"`
+	EndColumn int     `json:"-"`
+	Message   string  `json:"message"`
+	Hint      *string `json:"hint"`
+	Code      *string `json:"code"`
+	Severity  string  `json:"severity"`
+}
+
+// parseMypyLine returns the error of a line of the text output of mypy, if any
+func parseMypyLine(line string) *MypyError {
+	m := mypyLineRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	mypyError := &MypyError{File: m[1], Column: -1, Message: m[7], Severity: m[6]}
+	mypyError.Line, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		column, _ := strconv.Atoi(m[3])
+		mypyError.Column = column - 1
+	}
+	if m[4] != "" {
+		mypyError.EndLine, _ = strconv.Atoi(m[4])
+		mypyError.EndColumn, _ = strconv.Atoi(m[5])
+	}
+	if m[8] != "" {
+		mypyError.Code = &m[8]
+	}
+
+	return mypyError
+}
+
+// MypyParser reads mypy text and JSON reports
+type MypyParser struct {
+	options ParserOptions
+}
+
+// NewMypyParser creates a mypy parser
+func NewMypyParser(options ParserOptions) Parser {
+	return &MypyParser{options: options}
+}
+
+func (p *MypyParser) Name() string {
+	return ReportFormatMypy
+}
+
+func (p *MypyParser) Detect(data []byte) bool {
+	firstLine, _, _ := bytes.Cut(bytes.TrimSpace(data), []byte("\n"))
+	firstLine = bytes.TrimSpace(firstLine)
+
+	if bytes.HasPrefix(firstLine, []byte("{")) {
+		return bytes.Contains(firstLine, []byte(`"file"`)) && bytes.Contains(firstLine, []byte(`"hint"`))
+	}
+
+	// Nothing but the summary is printed when there is no error
+	return parseMypyLine(string(firstLine)) != nil || bytes.HasPrefix(firstLine, []byte("Success: no issues found"))
+}
+
+func (p *MypyParser) Parse(in io.Reader) ([]*Report, error) {
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatMypy
+	}
+
+	// Notes are attached to the error they follow, summary lines are skipped
+	mypyErrors := make([]*MypyError, 0)
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := bytes.TrimSpace(s.Bytes())
+
+		var mypyError *MypyError
+		if bytes.HasPrefix(line, []byte("{")) {
+			mypyError = &MypyError{}
+			if err := json.Unmarshal(line, mypyError); err != nil {
+				return nil, errors.New("could not parse the provided file, it must be a mypy report")
+			}
+		} else {
+			mypyError = parseMypyLine(string(line))
+		}
+
+		switch {
+		case mypyError == nil:
+		case mypyError.Severity == "note" && len(mypyErrors) > 0 && mypyErrors[len(mypyErrors)-1].File == mypyError.File:
+			mypyErrors[len(mypyErrors)-1].addHint(mypyError.Message)
+		default:
+			mypyErrors = append(mypyErrors, mypyError)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a mypy report")
+	}
+
+	reports := make([]*Report, 0, len(mypyErrors))
+	for _, mypyError := range mypyErrors {
+		reports = append(reports, NewReportFromMypy(mypyError, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+func (mypyError *MypyError) addHint(hint string) {
+	if mypyError.Hint == nil || *mypyError.Hint == "" {
+		mypyError.Hint = &hint
+		return
+	}
+
+	hints := *mypyError.Hint + "\n" + hint
+	mypyError.Hint = &hints
+}
+
+// NewReportFromMypy converts an error into a Report, its hints are used as body
+func NewReportFromMypy(mypyError *MypyError, reportType string, reportEngine string) *Report {
+	code := ""
+	if mypyError.Code != nil {
+		code = *mypyError.Code
+	}
+
+	column := 0
+	if mypyError.Column >= 0 {
+		column = mypyError.Column + 1
+	}
+
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   code,
+		Description: mypyError.Message,
+		Location: ReportLocation{
+			Path: mypyError.File,
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   mypyError.Line,
+					Column: column,
+				},
+				End: ReportLocationPositionsData{
+					Line:   mypyError.Line,
+					Column: column,
+				},
+			},
+		},
+	}
+
+	if mypyError.EndLine != 0 {
+		newReport.Location.Positions.End = ReportLocationPositionsData{
+			Line:   mypyError.EndLine,
+			Column: mypyError.EndColumn,
+		}
+	}
+
+	if mypyError.Hint != nil {
+		newReport.Content.Body = *mypyError.Hint
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(mypySeverity[mypyError.Severity])
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	newReport.Categories = []string{BugRisk}
+	if category := mypyCategory[code]; category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
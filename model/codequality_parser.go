@@ -0,0 +1,75 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Parser converts a source report of a given format into Gitlab compatible reports
+type Parser interface {
+	// Name returns the format name used to select the parser
+	Name() string
+	// Detect reports whether the content looks like a report in this format
+	Detect(data []byte) bool
+	// Parse reads a source report and returns its issues
+	Parse(in io.Reader) ([]*Report, error)
+}
+
+// ParserOptions holds the settings shared by every parser
+type ParserOptions struct {
+	ReportType   string
+	ReportEngine string
+}
+
+// ParserFactory creates a parser configured with the given options
+type ParserFactory func(options ParserOptions) Parser
+
+var parserRegistry = map[string]ParserFactory{}
+
+// RegisterParser makes a parser available under the given format name
+func RegisterParser(name string, factory ParserFactory) {
+	parserRegistry[name] = factory
+}
+
+// ParserNames returns the sorted names of the registered parsers
+func ParserNames() []string {
+	names := make([]string, 0, len(parserRegistry))
+	for name := range parserRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewParser returns the parser registered under name
+func NewParser(name string, options ParserOptions) (Parser, error) {
+	factory, exists := parserRegistry[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown report format %q, supported formats: %v", name, ParserNames())
+	}
+
+	return factory(options), nil
+}
+
+// DetectParser returns the first registered parser, by name, able to read the content
+func DetectParser(data []byte, options ParserOptions) (Parser, error) {
+	for _, name := range ParserNames() {
+		parser := parserRegistry[name](options)
+		if parser.Detect(data) {
+			return parser, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not detect the report format, supported formats: %v", ParserNames())
+}
+
+// IsEmptyReport reports whether the content has no issue in any format: text reports, e.g. of flake8,
+// are empty when there is no issue and JSON lists, e.g. of eslint, ruff or pylint, are empty.
+// An empty object is left to the parser, it may be a truncated report.
+func IsEmptyReport(data []byte) bool {
+	trimmedData := bytes.Join(bytes.Fields(data), nil)
+	return len(trimmedData) == 0 || bytes.Equal(trimmedData, []byte("[]"))
+}
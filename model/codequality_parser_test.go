@@ -0,0 +1,546 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	checkstyleReport = `<?xml version="1.0" encoding="utf-8"?>
+<checkstyle version="4.3">
+	<file name="src/index.ts">
+		<error line="4" column="28" severity="warning" message="'arg' is defined but never used." source="eslint.rules.@typescript-eslint/no-unused-vars" />
+	</file>
+</checkstyle>`
+
+	eslintReport = `[{
+	"filePath": "src/index.ts",
+	"messages": [{
+		"ruleId": "@typescript-eslint/no-unused-vars",
+		"severity": 1,
+		"message": "'arg' is defined but never used.",
+		"line": 4, "column": 28, "endLine": 4, "endColumn": 31,
+		"suggestions": [{"desc": "Remove unused variable 'arg'.", "fix": {"range": [60, 63], "text": ""}}]
+	}, {
+		"ruleId": "semi",
+		"severity": 2,
+		"message": "Missing semicolon.",
+		"line": 6, "column": 14, "endLine": 7, "endColumn": 1,
+		"fix": {"range": [90, 90], "text": ";"}
+	}],
+	"suppressedMessages": [{
+		"ruleId": "no-eval",
+		"severity": 2,
+		"message": "eval can be harmful.",
+		"line": 9, "column": 1,
+		"suppressions": [{"kind": "directive", "justification": ""}]
+	}],
+	"errorCount": 1,
+	"warningCount": 1
+}]`
+
+	pylintReport = `[{
+	"type": "warning", "module": "app", "obj": "main", "line": 3, "column": 4, "endLine": 3, "endColumn": 10,
+	"path": "app.py", "symbol": "eval-used", "message": "Use of eval", "message-id": "W0123"
+}, {
+	"type": "convention", "module": "app", "obj": "", "line": 1, "column": 0, "endLine": null, "endColumn": null,
+	"path": "app.py", "symbol": "missing-module-docstring", "message": "Missing module docstring", "message-id": "C0114"
+}]`
+
+	flake8Report = `app.py:1:1: F401 'os' imported but unused
+app.py:4:80: E501 line too long (88 > 79 characters)
+lib.py:7: [C901] 'parse' is too complex (12)
+3     E501 line too long (88 > 79 characters)`
+
+	ruffReport = `[{
+	"cell": null, "code": "S307", "message": "Use of possibly insecure function; consider using ` + "`ast.literal_eval`" + `",
+	"filename": "app.py", "location": {"column": 5, "row": 3}, "end_location": {"column": 15, "row": 3},
+	"fix": null, "noqa_row": 3, "url": "https://docs.astral.sh/ruff/rules/suspicious-eval-usage"
+}, {
+	"cell": null, "code": "F401", "message": "` + "`os`" + ` imported but unused",
+	"filename": "app.py", "location": {"column": 8, "row": 1}, "end_location": {"column": 10, "row": 1},
+	"fix": {"applicability": "safe", "message": "Remove unused import: ` + "`os`" + `", "edits": []},
+	"noqa_row": 1, "url": "https://docs.astral.sh/ruff/rules/unused-import"
+}]`
+
+	banditSarifReport = `{"runs": [{"tool": {"driver": {"name": "Bandit"}}, "results": [{"ruleId": "B602", "level": "error",
+	"message": {"text": "subprocess call with shell=True identified"},
+	"properties": {"issue_severity": "HIGH", "issue_confidence": "HIGH"}}]}], "version": "2.1.0"}`
+
+	semgrepSarifReport = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Semgrep OSS"}}, "results": [{"ruleId": "python.lang.security.audit.eval",
+	"message": {"text": "Detected the use of eval()"}, "properties": {}}], "invocations": [{"executionSuccessful": true}]}],
+	"paths": {"scanned": ["app.py"]}}`
+
+	ruffTextReport = `app.py:1:8: F401 [*] ` + "`os`" + ` imported but unused
+app.py:3:5: S307 Use of possibly insecure function; consider using ` + "`ast.literal_eval`" + `
+Found 2 errors.
+[*] 1 fixable with the ` + "`--fix`" + ` option.`
+
+	// The full format of ruff 0.12 and later
+	ruffFullTextReport = `F401 [*] ` + "`os`" + ` imported but unused
+ --> app.py:1:8
+  |
+1 | import os
+  |        ^^
+  |
+help: Remove unused import: ` + "`os`" + `
+
+S307 Use of possibly insecure function; consider using ` + "`ast.literal_eval`" + `
+ --> app.py:3:5
+  |
+3 |     eval(data)
+  |     ^^^^^^^^^^
+  |
+
+Found 2 errors.
+[*] 1 fixable with the ` + "`--fix`" + ` option.`
+
+	mypyReport = `app.py:12:5:12:9: error: Incompatible return value type (got "int", expected "str")  [return-value]
+app.py:12:5:12:9: note: Perhaps you need a type conversion
+app.py:1: error: Library stubs not installed for "yaml"  [import-untyped]
+Found 2 errors in 1 file (checked 1 source file)`
+
+	mypyJSONReport = `{"file": "app.py", "line": 12, "column": 4, "message": "Incompatible return value type (got \"int\", expected \"str\")", "hint": null, "code": "return-value", "severity": "error"}
+{"file": "app.py", "line": 0, "column": -1, "message": "Library stubs not installed for \"yaml\"", "hint": "Hint: \"python3 -m pip install types-PyYAML\"", "code": "import-untyped", "severity": "error"}`
+
+	gosecReport = `{
+	"Golang errors": {},
+	"Issues": [{
+		"severity": "MEDIUM", "confidence": "HIGH",
+		"cwe": {"id": "22", "url": "https://cwe.mitre.org/data/definitions/22.html"},
+		"rule_id": "G304", "details": "Potential file inclusion via variable",
+		"file": "cmd/main.go", "code": "12: data, err := os.ReadFile(name)\n", "line": "12-13", "column": "15",
+		"nosec": false, "suppressions": null
+	}, {
+		"severity": "LOW", "confidence": "HIGH", "cwe": {"id": "703"}, "rule_id": "G104", "details": "Errors unhandled.",
+		"file": "cmd/main.go", "line": "20", "column": "2", "nosec": true, "suppressions": null
+	}],
+	"Stats": {"files": 1, "lines": 40, "nosec": 1, "found": 1}
+}`
+
+	banditReport = `{
+	"errors": [],
+	"generated_at": "2026-10-18T10:00:00Z",
+	"metrics": {},
+	"results": [{
+		"code": "4 subprocess.call(cmd, shell=True)\n",
+		"col_offset": 0, "end_col_offset": 33, "filename": "./app.py",
+		"issue_confidence": "HIGH", "issue_cwe": {"id": 78, "link": "https://cwe.mitre.org/data/definitions/78.html"},
+		"issue_severity": "HIGH", "issue_text": "subprocess call with shell=True identified, security issue.",
+		"line_number": 4, "line_range": [4, 5],
+		"more_info": "https://bandit.readthedocs.io/en/latest/plugins/b602_subprocess_popen_with_shell_equals_true.html",
+		"test_id": "B602", "test_name": "subprocess_popen_with_shell_equals_true"
+	}]
+}`
+
+	semgrepReport = `{
+	"results": [{
+		"check_id": "python.lang.security.audit.eval-detected.eval-detected",
+		"path": "app.py",
+		"start": {"line": 3, "col": 5, "offset": 30}, "end": {"line": 3, "col": 15, "offset": 40},
+		"extra": {
+			"message": "Detected the use of eval().", "severity": "WARNING",
+			"metadata": {"category": "security", "confidence": "LOW", "cwe": ["CWE-95: Improper Neutralization of Directives in Dynamically Evaluated Code ('Eval Injection')"],
+				"references": ["https://owasp.org/Top10/A03_2021-Injection"], "source": "https://semgrep.dev/r/python.lang.security.audit.eval-detected.eval-detected"},
+			"is_ignored": false
+		}
+	}, {
+		"check_id": "python.lang.correctness.useless-comparison",
+		"path": "app.py",
+		"start": {"line": 8, "col": 1}, "end": {"line": 8, "col": 7},
+		"extra": {"message": "This comparison is useless.", "severity": "ERROR", "metadata": {"category": "correctness", "cwe": "CWE-0"}, "is_ignored": false}
+	}, {
+		"check_id": "python.lang.security.audit.eval-detected.eval-detected",
+		"path": "app.py",
+		"start": {"line": 9, "col": 1}, "end": {"line": 9, "col": 7},
+		"extra": {"message": "Detected the use of eval().", "severity": "WARNING", "metadata": {}, "is_ignored": true}
+	}],
+	"errors": [],
+	"paths": {"scanned": ["app.py"]}
+}`
+
+	golangciReport = `{
+	"Issues": [{
+		"FromLinter": "errcheck",
+		"Text": "Error return value of ` + "`f.Close`" + ` is not checked",
+		"SourceLines": ["\tdefer f.Close()"],
+		"Replacement": null,
+		"Pos": {"Filename": "cmd/main.go", "Offset": 120, "Line": 12, "Column": 13}
+	}, {
+		"FromLinter": "gofmt",
+		"Text": "File is not ` + "`gofmt`" + `-ed",
+		"Severity": "warning",
+		"SourceLines": ["x :=  1"],
+		"Replacement": {"NeedOnlyDelete": false, "NewLines": null, "Inline": {"StartCol": 2, "Length": 4, "NewString": ":= "}},
+		"Pos": {"Filename": "cmd/main.go", "Line": 20, "Column": 1},
+		"LineRange": {"From": 20, "To": 20}
+	}, {
+		"FromLinter": "staticcheck",
+		"Text": "SA1019: ioutil.ReadFile has been deprecated",
+		"Severity": "HIGH",
+		"SourceLines": ["\tdata, err := ioutil.ReadFile(path)"],
+		"Pos": {"Filename": "cmd/main.go", "Line": 31, "Column": 15}
+	}],
+	"Report": {"Linters": [{"Name": "errcheck", "Enabled": true}]}
+}`
+)
+
+func TestDetectParser(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "bandit", data: banditReport, expected: ReportFormatBandit},
+		{name: "checkstyle", data: checkstyleReport, expected: ReportFormatCheckstyle},
+		{name: "eslint", data: eslintReport, expected: ReportFormatEslint},
+		{name: "flake8", data: flake8Report, expected: ReportFormatFlake8},
+		{name: "golangci-lint", data: golangciReport, expected: ReportFormatGolangci},
+		{name: "gosec", data: gosecReport, expected: ReportFormatGosec},
+		{name: "mypy", data: mypyReport, expected: ReportFormatMypy},
+		{name: "pylint", data: pylintReport, expected: ReportFormatPylint},
+		{name: "ruff", data: ruffReport, expected: ReportFormatRuff},
+		{name: "sarif", data: sarifReport, expected: ReportFormatSarif},
+		{name: "semgrep", data: semgrepReport, expected: ReportFormatSemgrep},
+		// Reports of a tool in the format of another one
+		{name: "ruff text", data: ruffTextReport, expected: ReportFormatRuff},
+		{name: "ruff full text", data: ruffFullTextReport, expected: ReportFormatRuff},
+		{name: "ruff clean run", data: "All checks passed!\n", expected: ReportFormatRuff},
+		{name: "ruff text without fixable diagnostic", data: "app.py:3:5: S307 Use of possibly insecure function\nFound 1 error.\n", expected: ReportFormatRuff},
+		{name: "bandit sarif", data: banditSarifReport, expected: ReportFormatSarif},
+		{name: "semgrep sarif", data: semgrepSarifReport, expected: ReportFormatSarif},
+		{name: "flake8 with a ruff like message", data: "app.py:3:5: E999 SyntaxError: Found 1 error.", expected: ReportFormatFlake8},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := DetectParser([]byte(tt.data), ParserOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if parser.Name() != tt.expected {
+				t.Errorf("expected format %s, got %s", tt.expected, parser.Name())
+			}
+		})
+	}
+
+	for _, data := range []string{"plain text", "[]", "{}"} {
+		if parser, err := DetectParser([]byte(data), ParserOptions{}); err == nil {
+			t.Errorf("expected an error for %q, got the %s format", data, parser.Name())
+		}
+	}
+}
+
+func TestIsEmptyReport(t *testing.T) {
+	for data, expected := range map[string]bool{
+		"":           true,
+		" \n":        true,
+		"[]":         true,
+		"[\n]\n":     true,
+		"{ }":        false,
+		"[{}]":       false,
+		flake8Report: false,
+		ruffReport:   false,
+	} {
+		if IsEmptyReport([]byte(data)) != expected {
+			t.Errorf("expected %t for %q", expected, data)
+		}
+	}
+}
+
+func TestNewParserUnknownFormat(t *testing.T) {
+	if _, err := NewParser("unknown", ParserOptions{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestCheckStyleParser(t *testing.T) {
+	parser, _ := NewParser(ReportFormatCheckstyle, ParserOptions{ReportType: ReportTypeIssue, ReportEngine: ReportEngineEslint})
+
+	reports, err := parser.Parse(strings.NewReader(checkstyleReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+
+	if reports[0].CheckName != checkName || reports[0].Severity != SeverityMinor || reports[0].Location.Positions.Begin.Line != 4 {
+		t.Errorf("unexpected report %+v", reports[0])
+	}
+}
+
+func TestGolangciParser(t *testing.T) {
+	parser, _ := NewParser(ReportFormatGolangci, ParserOptions{ReportType: ReportTypeIssue, ReportEngine: "golangci-lint"})
+
+	reports, err := parser.Parse(strings.NewReader(golangciReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 3 {
+		t.Fatalf("expected 3 reports, got %d", len(reports))
+	}
+
+	r := reports[0]
+	if r.EngineName != "errcheck" || r.CheckName != "errcheck" || r.Severity != SeverityMajor || r.Categories[0] != BugRisk {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Content.Body != "```go\n\tdefer f.Close()\n```" {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+
+	r = reports[1]
+	if r.EngineName != "gofmt" || r.Severity != SeverityMinor || r.Categories[0] != Style {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.Begin.Column != 3 || r.Location.Positions.End.Column != 7 || r.Location.Positions.End.Line != 20 {
+		t.Errorf("unexpected positions %+v", r.Location.Positions)
+	}
+
+	if !strings.Contains(r.Content.Body, "Suggested fix: replace with `:= `") {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+
+	r = reports[2]
+	if r.EngineName != "staticcheck" || r.CheckName != "SA1019" || r.Severity != SeverityCritical || r.Categories[0] != Compatibility {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
+
+func TestEslintParser(t *testing.T) {
+	parser, _ := NewParser(ReportFormatEslint, ParserOptions{ReportType: ReportTypeIssue})
+
+	reports, err := parser.Parse(strings.NewReader(eslintReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, the suppressed one left out, got %d", len(reports))
+	}
+
+	r := reports[0]
+	if r.EngineName != ReportEngineEslint || r.CheckName != checkName || r.Severity != SeverityMinor || r.Categories[0] != BugRisk {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.End.Line != 4 || r.Location.Positions.End.Column != 31 {
+		t.Errorf("unexpected end position %+v", r.Location.Positions.End)
+	}
+
+	if r.Content.Body != "Suggestion: Remove unused variable 'arg'." {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+
+	r = reports[1]
+	if r.CheckName != "semi" || r.Severity != SeverityMajor || r.Categories[0] != Clarity {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.End.Line != 7 || r.Content.Body != "Fixable with `eslint --fix`" {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
+
+func TestEslintParserEngine(t *testing.T) {
+	parser, _ := NewParser(ReportFormatEslint, ParserOptions{ReportType: ReportTypeIssue, ReportEngine: "frontend"})
+
+	reports, err := parser.Parse(strings.NewReader(eslintReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := reports[0]
+	if r.EngineName != "frontend" || r.CheckName != checkName || r.Categories[0] != BugRisk {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
+
+func parseReport(t *testing.T, format string, data string, expected int) []*Report {
+	t.Helper()
+
+	parser, _ := NewParser(format, ParserOptions{ReportType: ReportTypeIssue})
+
+	reports, err := parser.Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != expected {
+		t.Fatalf("expected %d reports, got %d", expected, len(reports))
+	}
+
+	return reports
+}
+
+func TestPylintParser(t *testing.T) {
+	reports := parseReport(t, ReportFormatPylint, pylintReport, 2)
+
+	r := reports[0]
+	if r.EngineName != ReportFormatPylint || r.CheckName != "eval-used" || r.Severity != SeverityMinor || r.Categories[0] != Security {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.Begin.Column != 5 || r.Location.Positions.End.Column != 11 {
+		t.Errorf("unexpected positions %+v", r.Location.Positions)
+	}
+
+	r = reports[1]
+	if r.Severity != SeverityInfo || r.Categories[0] != Style || r.Location.Positions.End.Column != 1 {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Content.Body != "C0114: https://pylint.readthedocs.io/en/latest/user_guide/messages/convention/missing-module-docstring.html" {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+}
+
+func TestFlake8Parser(t *testing.T) {
+	reports := parseReport(t, ReportFormatFlake8, flake8Report, 3)
+
+	for idx, expected := range []struct {
+		checkName string
+		severity  string
+		category  string
+	}{
+		{"F401", SeverityMinor, Clarity},
+		{"E501", SeverityMinor, Style},
+		{"C901", SeverityMinor, Complexity},
+	} {
+		r := reports[idx]
+		if r.CheckName != expected.checkName || r.Severity != expected.severity || r.Categories[0] != expected.category {
+			t.Errorf("unexpected report %+v", r)
+		}
+	}
+
+	if reports[2].Location.Path != "lib.py" || reports[2].Location.Positions.Begin.Line != 7 {
+		t.Errorf("unexpected location %+v", reports[2].Location)
+	}
+}
+
+func TestRuffParser(t *testing.T) {
+	reports := parseReport(t, ReportFormatRuff, ruffReport, 2)
+
+	r := reports[0]
+	if r.CheckName != "S307" || r.Severity != SeverityMajor || r.Categories[0] != Security {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.End.Column != 15 || r.Content.Body != "https://docs.astral.sh/ruff/rules/suspicious-eval-usage" {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	r = reports[1]
+	if r.Categories[0] != Clarity || !strings.HasPrefix(r.Content.Body, "Fixable with `ruff check --fix`: Remove unused import") {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
+
+func TestRuffParserText(t *testing.T) {
+	for _, data := range []string{ruffTextReport, ruffFullTextReport} {
+		reports := parseReport(t, ReportFormatRuff, data, 2)
+
+		r := reports[0]
+		if r.CheckName != "F401" || r.Description != "`os` imported but unused" || r.Content.Body != "Fixable with `ruff check --fix`" {
+			t.Errorf("unexpected report %+v", r)
+		}
+
+		r = reports[1]
+		if r.CheckName != "S307" || r.Categories[0] != Security || r.Location.Path != "app.py" || r.Location.Positions.Begin.Line != 3 ||
+			r.Location.Positions.Begin.Column != 5 || r.Content.Body != "" {
+			t.Errorf("unexpected report %+v", r)
+		}
+	}
+
+	parseReport(t, ReportFormatRuff, "All checks passed!\n", 0)
+
+	// A text report without any diagnostic is not a clean run
+	parser, _ := NewParser(ReportFormatRuff, ParserOptions{ReportType: ReportTypeIssue})
+	if _, err := parser.Parse(strings.NewReader("error: Failed to parse pyproject.toml\n")); err == nil {
+		t.Error("expected an error for a text report without diagnostics")
+	}
+}
+
+func TestMypyParser(t *testing.T) {
+	for _, data := range []string{mypyReport, mypyJSONReport} {
+		reports := parseReport(t, ReportFormatMypy, data, 2)
+
+		r := reports[0]
+		if r.CheckName != "return-value" || r.Severity != SeverityMajor || r.Categories[0] != BugRisk {
+			t.Errorf("unexpected report %+v", r)
+		}
+
+		if r.Location.Positions.Begin.Line != 12 || r.Location.Positions.Begin.Column != 5 {
+			t.Errorf("unexpected positions %+v", r.Location.Positions)
+		}
+
+		if reports[1].Categories[0] != Compatibility {
+			t.Errorf("unexpected report %+v", reports[1])
+		}
+	}
+
+	if parser, err := DetectParser([]byte("Success: no issues found in 3 source files\n"), ParserOptions{}); err != nil || parser.Name() != ReportFormatMypy {
+		t.Errorf("expected the summary of mypy to be detected, got %v", err)
+	}
+
+	reports := parseReport(t, ReportFormatMypy, mypyReport, 2)
+	if reports[0].Content.Body != "Perhaps you need a type conversion" || reports[0].Location.Positions.End.Column != 9 {
+		t.Errorf("unexpected report %+v", reports[0])
+	}
+}
+
+func TestGosecParser(t *testing.T) {
+	reports := parseReport(t, ReportFormatGosec, gosecReport, 1)
+
+	r := reports[0]
+	if r.CheckName != "G304" || r.Severity != SeverityMajor || r.Categories[0] != Security {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.Begin.Line != 12 || r.Location.Positions.End.Line != 13 || r.Location.Positions.Begin.Column != 15 {
+		t.Errorf("unexpected positions %+v", r.Location.Positions)
+	}
+
+	if r.Content.Body != "CWE-22 (https://cwe.mitre.org/data/definitions/22.html)" {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+}
+
+func TestBanditParser(t *testing.T) {
+	reports := parseReport(t, ReportFormatBandit, banditReport, 1)
+
+	r := reports[0]
+	if r.CheckName != "B602" || r.Severity != SeverityCritical || r.Categories[0] != Security || r.Location.Path != "app.py" {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Location.Positions.Begin.Column != 1 || r.Location.Positions.End.Line != 5 || r.Location.Positions.End.Column != 34 {
+		t.Errorf("unexpected positions %+v", r.Location.Positions)
+	}
+
+	if !strings.HasPrefix(r.Content.Body, "CWE-78 (https://cwe.mitre.org/data/definitions/78.html)\nhttps://bandit.readthedocs.io/") {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+}
+
+func TestSemgrepParser(t *testing.T) {
+	reports := parseReport(t, ReportFormatSemgrep, semgrepReport, 2)
+
+	// A warning in which semgrep has a low confidence
+	r := reports[0]
+	if r.Severity != SeverityMinor || r.Categories[0] != Security || r.Location.Positions.End.Column != 15 {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if !strings.HasPrefix(r.Content.Body, "CWE-95: Improper Neutralization") || !strings.Contains(r.Content.Body, "(https://cwe.mitre.org/data/definitions/95.html)\nhttps://semgrep.dev/r/") {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+
+	r = reports[1]
+	if r.Severity != SeverityCritical || r.Categories[0] != BugRisk || r.Content.Body != "" {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
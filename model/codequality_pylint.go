@@ -0,0 +1,140 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+func init() {
+	RegisterParser(ReportFormatPylint, NewPylintParser)
+}
+
+// PylintMessage represents a message of the JSON output of pylint (--output-format=json),
+// or of the json2 output of pylint 3 whose messages are wrapped in {"messages": [...], "statistics": {...}}
+// [{"type": "convention", "symbol": "missing-module-docstring", "message-id": "C0114", "path": "app.py", ...}]
+//
+// References:
+// https://pylint.readthedocs.io/en/latest/user_guide/usage/output.html
+type PylintMessage struct {
+	Type        string `json:"type"`
+	Symbol      string `json:"symbol"`
+	Message     string `json:"message"`
+	MessageID   string `json:"message-id"`
+	MessageIDV2 string `json:"messageId"`
+	Path        string `json:"path"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	EndLine     *int   `json:"endLine"`
+	EndColumn   *int   `json:"endColumn"`
+}
+
+// PylintResult represents the json2 output of pylint
+type PylintResult struct {
+	Messages []*PylintMessage `json:"messages"`
+}
+
+// PylintParser reads pylint JSON reports
+type PylintParser struct {
+	options ParserOptions
+}
+
+// NewPylintParser creates a pylint JSON parser
+func NewPylintParser(options ParserOptions) Parser {
+	return &PylintParser{options: options}
+}
+
+func (p *PylintParser) Name() string {
+	return ReportFormatPylint
+}
+
+func (p *PylintParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("[")) && bytes.Contains(trimmedData, []byte(`"message-id"`)) ||
+		bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"messageId"`))
+}
+
+func (p *PylintParser) Parse(in io.Reader) ([]*Report, error) {
+	byteValue, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*PylintMessage
+	if bytes.HasPrefix(bytes.TrimSpace(byteValue), []byte("{")) {
+		var result PylintResult
+		err = json.Unmarshal(byteValue, &result)
+		messages = result.Messages
+	} else {
+		err = json.Unmarshal(byteValue, &messages)
+	}
+	if err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a pylint json report")
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatPylint
+	}
+
+	reports := make([]*Report, 0, len(messages))
+	for _, message := range messages {
+		reports = append(reports, NewReportFromPylint(message, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+// NewReportFromPylint converts a message into a Report, pylint columns are 0 based
+func NewReportFromPylint(message *PylintMessage, reportType string, reportEngine string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   message.Symbol,
+		Description: message.Message,
+		Location: ReportLocation{
+			Path: message.Path,
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   message.Line,
+					Column: message.Column + 1,
+				},
+				End: ReportLocationPositionsData{
+					Line:   message.Line,
+					Column: message.Column + 1,
+				},
+			},
+		},
+	}
+
+	if message.EndLine != nil && message.EndColumn != nil {
+		newReport.Location.Positions.End = ReportLocationPositionsData{
+			Line:   *message.EndLine,
+			Column: *message.EndColumn + 1,
+		}
+	}
+
+	messageID := message.MessageID
+	if messageID == "" {
+		messageID = message.MessageIDV2
+	}
+	if messageID != "" && message.Symbol != "" {
+		newReport.Content.Body = messageID + ": https://pylint.readthedocs.io/en/latest/user_guide/messages/" + message.Type + "/" + message.Symbol + ".html"
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(pylintSeverity[message.Type])
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	if category := pylintCategory[message.Symbol]; category != "" {
+		newReport.Categories = []string{category}
+	} else if category := pylintCategory[message.Type]; category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
@@ -0,0 +1,170 @@
+package model
+
+// pythonCodeLookup returns the value of a flake8 or ruff code by its longest prefix in the table, e.g. F401 then F
+func pythonCodeLookup(table map[string]string, code string) string {
+	for n := len(code); n > 0; n-- {
+		if value, exists := table[code[:n]]; exists {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Codes of flake8 and its plugins, shared by ruff which implements them under the same prefixes.
+//
+// References:
+// https://flake8.pycqa.org/en/latest/user/error-codes.html
+// https://pycodestyle.pycqa.org/en/latest/intro.html#error-codes
+// https://docs.astral.sh/ruff/rules/
+var pythonCodeCategory = map[string]string{
+	"A":     BugRisk,
+	"ANN":   Style,
+	"ARG":   Clarity,
+	"ASYNC": BugRisk,
+	"B":     BugRisk,
+	"BLE":   BugRisk,
+	"C4":    Clarity,
+	"C90":   Complexity,
+	"COM":   Style,
+	"D":     Style,
+	"DTZ":   BugRisk,
+	"E":     Style,
+	"E9":    BugRisk,
+	"EM":    Style,
+	"ERA":   Clarity,
+	"EXE":   BugRisk,
+	"F":     BugRisk,
+	"F401":  Clarity,
+	"F841":  Clarity,
+	"FBT":   Clarity,
+	"G":     Style,
+	"I":     Style,
+	"ISC":   Style,
+	"N":     Style,
+	"PERF":  Complexity,
+	"PGH":   BugRisk,
+	"PIE":   Clarity,
+	"PLC":   Style,
+	"PLE":   BugRisk,
+	"PLR":   Clarity,
+	"PLR09": Complexity,
+	"PLW":   BugRisk,
+	"PT":    Style,
+	"PTH":   Clarity,
+	"Q":     Style,
+	"RET":   Clarity,
+	"RSE":   Style,
+	"RUF":   Clarity,
+	"S":     Security,
+	"SIM":   Clarity,
+	"SLF":   Clarity,
+	"T20":   Clarity,
+	"TRY":   Clarity,
+	"UP":    Compatibility,
+	"W":     Style,
+	"W6":    Compatibility,
+	"YTT":   Compatibility,
+}
+
+var pythonCodeSeverity = map[string]string{
+	"A":     SeverityMinor,
+	"ANN":   SeverityInfo,
+	"ASYNC": SeverityMajor,
+	"B":     SeverityMajor,
+	"BLE":   SeverityMajor,
+	"C90":   SeverityMinor,
+	"COM":   SeverityInfo,
+	"D":     SeverityInfo,
+	"DTZ":   SeverityMajor,
+	"E":     SeverityMinor,
+	"E9":    SeverityCritical,
+	"F":     SeverityMajor,
+	"F401":  SeverityMinor,
+	"F841":  SeverityMinor,
+	"I":     SeverityInfo,
+	"ISC":   SeverityInfo,
+	"N":     SeverityInfo,
+	"PLE":   SeverityMajor,
+	"PLW":   SeverityMajor,
+	"Q":     SeverityInfo,
+	"S":     SeverityMajor,
+	"W":     SeverityInfo,
+	"W6":    SeverityMinor,
+}
+
+// Messages of pylint are categorized by symbol, or by the type of the message otherwise.
+//
+// References:
+// https://pylint.readthedocs.io/en/latest/user_guide/messages/messages_overview.html
+var pylintCategory = map[string]string{
+	"fatal":      BugRisk,
+	"error":      BugRisk,
+	"warning":    BugRisk,
+	"refactor":   Clarity,
+	"convention": Style,
+	"info":       Clarity,
+
+	"bad-builtin":                   Clarity,
+	"consider-using-with":           BugRisk,
+	"deprecated-method":             Compatibility,
+	"deprecated-module":             Compatibility,
+	"duplicate-code":                Clarity,
+	"eval-used":                     Security,
+	"exec-used":                     Security,
+	"fixme":                         Clarity,
+	"import-error":                  Compatibility,
+	"line-too-long":                 Style,
+	"too-complex":                   Complexity,
+	"too-many-arguments":            Complexity,
+	"too-many-boolean-expressions":  Complexity,
+	"too-many-branches":             Complexity,
+	"too-many-instance-attributes":  Complexity,
+	"too-many-lines":                Complexity,
+	"too-many-locals":               Complexity,
+	"too-many-nested-blocks":        Complexity,
+	"too-many-positional-arguments": Complexity,
+	"too-many-public-methods":       Complexity,
+	"too-many-return-statements":    Complexity,
+	"too-many-statements":           Complexity,
+	"unused-argument":               Clarity,
+	"unused-import":                 Clarity,
+	"unused-variable":               Clarity,
+	"weak-cryptography":             Security,
+}
+
+var pylintSeverity = map[string]string{
+	"fatal":      SeverityCritical,
+	"error":      SeverityMajor,
+	"warning":    SeverityMinor,
+	"refactor":   SeverityInfo,
+	"convention": SeverityInfo,
+	"info":       SeverityInfo,
+}
+
+// Error codes of mypy, every other code is a type error.
+//
+// References:
+// https://mypy.readthedocs.io/en/stable/error_code_list.html
+// https://mypy.readthedocs.io/en/stable/error_code_list2.html
+var mypyCategory = map[string]string{
+	"annotation-unchecked": Clarity,
+	"deprecated":           Compatibility,
+	"import":               Compatibility,
+	"import-not-found":     Compatibility,
+	"import-untyped":       Compatibility,
+	"no-untyped-call":      Clarity,
+	"no-untyped-def":       Clarity,
+	"redundant-cast":       Clarity,
+	"redundant-expr":       Clarity,
+	"syntax":               BugRisk,
+	"unreachable":          BugRisk,
+	"unused-ignore":        Clarity,
+	"var-annotated":        Clarity,
+}
+
+var mypySeverity = map[string]string{
+	"error":   SeverityMajor,
+	"warning": SeverityMinor,
+	"note":    SeverityInfo,
+}
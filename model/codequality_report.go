@@ -5,6 +5,10 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/hashstructure/v2"
@@ -40,7 +44,20 @@ const (
 
 	ReportTypeIssue = "issue"
 
-	ReportEngineEslint = "eslint"
+	ReportEngineEslint   = "eslint"
+	ReportEngineGolangci = "golangci-lint"
+
+	ReportFormatBandit     = "bandit"
+	ReportFormatCheckstyle = "checkstyle"
+	ReportFormatEslint     = "eslint"
+	ReportFormatFlake8     = "flake8"
+	ReportFormatGolangci   = "golangci-lint"
+	ReportFormatGosec      = "gosec"
+	ReportFormatMypy       = "mypy"
+	ReportFormatPylint     = "pylint"
+	ReportFormatRuff       = "ruff"
+	ReportFormatSarif      = "sarif"
+	ReportFormatSemgrep    = "semgrep"
 )
 
 type ReportContent struct {
@@ -133,8 +150,7 @@ func (r *Report) SetSeverity(severity string) {
 func (r *Report) SetCheckName() {
 	switch r.EngineName {
 	case ReportEngineEslint:
-		checkNameSplit := strings.Split(r.CheckName, "/")
-		r.CheckName = checkNameSplit[len(checkNameSplit)-1]
+		r.CheckName = eslintCheckName(r.CheckName)
 	}
 }
 
@@ -147,19 +163,60 @@ func (r *Report) SetCategories() {
 		if eslintCategory[r.CheckName] != "" {
 			r.Categories = []string{eslintCategory[r.CheckName]}
 		}
+	case ReportEngineGolangci:
+		// The check name is the linter, e.g. the source of the checkstyle report of golangci-lint
+		if category := golangciCategory(r.CheckName, r.Description); category != "" {
+			r.Categories = []string{category}
+		}
 	}
 }
 
-func (r *Report) ComputeFingerprint() {
+// fingerprintKey holds the parts of a report identifying an issue independently of its position,
+// so the fingerprint survives unrelated edits that shift the issue to another line
+type fingerprintKey struct {
+	EngineName  string
+	CheckName   string
+	Path        string
+	Description string
+	Occurrence  int
+}
+
+var fingerprintNumberRe = regexp.MustCompile(`[0-9]+`)
+
+func (r *Report) fingerprintKey() fingerprintKey {
+	// Numbers in messages usually refer to lines or counters that change between runs
+	description := fingerprintNumberRe.ReplaceAllString(r.Description, "#")
 
-	issueReport := Report{
+	return fingerprintKey{
+		EngineName:  r.EngineName,
 		CheckName:   r.CheckName,
-		Location:    ReportLocation{Path: r.Location.Path},
-		Description: r.Description,
+		Path:        fingerprintPath(r.Location.Path),
+		Description: strings.Join(strings.Fields(description), " "),
+	}
+}
+
+// fingerprintPath returns the path relative to the working directory using forward slashes
+func fingerprintPath(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if relPath, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(relPath, "..") {
+				path = relPath
+			}
+		}
 	}
 
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// ComputeFingerprint assigns a fingerprint to the report, assuming it is the only occurrence
+// of the issue in its file. Use ComputeFingerprints to fingerprint a complete list of reports.
+func (r *Report) ComputeFingerprint() {
+	r.computeFingerprint(r.fingerprintKey())
+}
+
+func (r *Report) computeFingerprint(issueKey fingerprintKey) {
 	// Generate an hash of the reported problem
-	hash, err := hashstructure.Hash(issueReport, hashstructure.FormatV2, nil)
+	hash, err := hashstructure.Hash(issueKey, hashstructure.FormatV2, nil)
 	if err != nil {
 		return
 	}
@@ -171,12 +228,28 @@ func (r *Report) ComputeFingerprint() {
 	hasher := md5.New()
 	hasher.Write(b)
 
-	switch r.EngineName {
-	case ReportEngineEslint:
-		if r.CheckName == Complexity {
-			r.Fingerprint = hex.EncodeToString(hasher.Sum(nil))
-		}
+	r.Fingerprint = hex.EncodeToString(hasher.Sum(nil))
+}
 
+// ComputeFingerprints assigns a fingerprint to every report. Identical issues within a file
+// are told apart by their occurrence index, ordered by position.
+func ComputeFingerprints(reports []*Report) {
+	sortedReports := make([]*Report, len(reports))
+	copy(sortedReports, reports)
+
+	sort.SliceStable(sortedReports, func(i, j int) bool {
+		bi, bj := sortedReports[i].Location.Positions.Begin, sortedReports[j].Location.Positions.Begin
+		return bi.Line < bj.Line || bi.Line == bj.Line && bi.Column < bj.Column
+	})
+
+	occurrences := make(map[fingerprintKey]int)
+	for _, r := range sortedReports {
+		issueKey := r.fingerprintKey()
+		occurrence := occurrences[issueKey]
+		occurrences[issueKey]++
+
+		issueKey.Occurrence = occurrence
+		r.computeFingerprint(issueKey)
 	}
 }
 
@@ -0,0 +1,284 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatRuff, NewRuffParser)
+}
+
+// RuffDiagnostic represents a diagnostic of the JSON output of ruff (ruff check --output-format json).
+// [{"code": "F401", "message": "...", "filename": "/src/app.py", "location": {...}, "end_location": {...}, ...}]
+//
+// References:
+// https://docs.astral.sh/ruff/settings/#output-format
+type RuffDiagnostic struct {
+	Code        string        `json:"code"`
+	Message     string        `json:"message"`
+	Filename    string        `json:"filename"`
+	Location    RuffLocation  `json:"location"`
+	EndLocation *RuffLocation `json:"end_location"`
+	Fix         *RuffFix      `json:"fix"`
+	URL         string        `json:"url"`
+}
+
+// Lines of the text output of ruff (ruff check --output-format concise or full). Until ruff 0.12 every
+// diagnostic starts with its location, as in flake8, it is then told apart by a fixable diagnostic or the summary.
+// app.py:1:8: F401 [*] `os` imported but unused
+// Found 1 error.
+// Since ruff 0.12 the full format starts with the rule and gives the location on the next line.
+// F401 [*] `os` imported but unused
+//
+//	--> app.py:1:8
+var (
+	ruffTextRe     = regexp.MustCompile(`(?m)^(?:.+?:\d+:\d+: [A-Z]+[0-9]+ \[\*\] |Found \d+ errors?\.|All checks passed!)`)
+	ruffHeaderRe   = regexp.MustCompile(`^(?:([A-Z]+[0-9]+)|SyntaxError:|invalid-syntax:) (.*)$`)
+	ruffLocationRe = regexp.MustCompile(`^-->\s*(.+?):(\d+):(\d+)$`)
+)
+
+const ruffNoIssues = "All checks passed!"
+
+// RuffLocation represents a position, columns are 1 based and the end column is exclusive
+type RuffLocation struct {
+	Row    int `json:"row"`
+	Column int `json:"column"`
+}
+
+// RuffFix represents the fix of a diagnostic, unsafe fixes are only applied with --unsafe-fixes
+type RuffFix struct {
+	Applicability string `json:"applicability"`
+	Message       string `json:"message"`
+}
+
+// isRuffText tells whether data is the text output of ruff
+func isRuffText(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	firstLine, rest, _ := bytes.Cut(trimmedData, []byte("\n"))
+	firstLine = bytes.TrimSpace(firstLine)
+	secondLine, _, _ := bytes.Cut(rest, []byte("\n"))
+
+	switch {
+	case bytes.Equal(firstLine, []byte(ruffNoIssues)):
+		return true
+	case ruffHeaderRe.Match(firstLine):
+		return ruffLocationRe.Match(bytes.TrimSpace(secondLine))
+	case flake8DefaultRe.Match(firstLine):
+		return ruffTextRe.Match(trimmedData)
+	}
+
+	return false
+}
+
+// RuffParser reads ruff JSON and text reports
+type RuffParser struct {
+	options ParserOptions
+}
+
+// NewRuffParser creates a ruff parser
+func NewRuffParser(options ParserOptions) Parser {
+	return &RuffParser{options: options}
+}
+
+func (p *RuffParser) Name() string {
+	return ReportFormatRuff
+}
+
+func (p *RuffParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return (bytes.HasPrefix(trimmedData, []byte("[")) && bytes.Contains(trimmedData, []byte(`"noqa_row"`))) || isRuffText(data)
+}
+
+func (p *RuffParser) Parse(in io.Reader) ([]*Report, error) {
+	reader := bufio.NewReader(in)
+
+	var diagnostics []*RuffDiagnostic
+	if isJSONArray(reader) {
+		if err := json.NewDecoder(reader).Decode(&diagnostics); err != nil {
+			return nil, errors.New("could not parse the provided file, it must be a ruff json report")
+		}
+	} else {
+		var err error
+		if diagnostics, err = parseRuffText(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatRuff
+	}
+
+	reports := make([]*Report, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		reports = append(reports, NewReportFromRuff(diagnostic, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+// isJSONArray tells whether the next non blank byte of the reader starts a JSON array
+func isJSONArray(reader *bufio.Reader) bool {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return false
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			_ = reader.UnreadByte()
+			return b == '['
+		}
+	}
+}
+
+// parseRuffText reads the diagnostics of the text output, the source lines and the help of the full format are skipped.
+// Only the fixes applied by ruff check --fix are marked with [*].
+func parseRuffText(in io.Reader) ([]*RuffDiagnostic, error) {
+	diagnostics := make([]*RuffDiagnostic, 0)
+	noIssues := false
+
+	// The diagnostic of the last rule line, waiting for its location
+	var pending *RuffDiagnostic
+
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+
+		if m := ruffLocationRe.FindStringSubmatch(line); m != nil && pending != nil {
+			pending.Filename = m[1]
+			pending.Location.Row, _ = strconv.Atoi(m[2])
+			pending.Location.Column, _ = strconv.Atoi(m[3])
+			diagnostics = append(diagnostics, pending)
+			pending = nil
+			continue
+		}
+
+		if m := ruffHeaderRe.FindStringSubmatch(line); m != nil {
+			pending = newRuffTextDiagnostic(m[1], m[2])
+			continue
+		}
+
+		if line == ruffNoIssues {
+			noIssues = true
+			continue
+		}
+
+		flake8Error := parseFlake8Line(line)
+		if flake8Error == nil || flake8Error.Column == 0 {
+			continue
+		}
+
+		diagnostic := newRuffTextDiagnostic(flake8Error.Code, flake8Error.Message)
+		diagnostic.Filename = flake8Error.Path
+		diagnostic.Location = RuffLocation{Row: flake8Error.Line, Column: flake8Error.Column}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	// A report without any diagnostic is only valid when ruff says so, it is not a ruff report otherwise
+	if err := s.Err(); err != nil || (len(diagnostics) == 0 && !noIssues) {
+		return nil, errors.New("could not parse the provided file, it must be a ruff json or text report")
+	}
+
+	return diagnostics, nil
+}
+
+// newRuffTextDiagnostic creates a diagnostic from a line of the text output, without its location
+func newRuffTextDiagnostic(code string, message string) *RuffDiagnostic {
+	diagnostic := &RuffDiagnostic{Code: code, Message: message}
+	if message, fixable := strings.CutPrefix(message, "[*] "); fixable {
+		diagnostic.Message = message
+		diagnostic.Fix = &RuffFix{Applicability: "safe"}
+	}
+
+	return diagnostic
+}
+
+// NewReportFromRuff converts a diagnostic into a Report, the file path is made relative to the working directory
+func NewReportFromRuff(diagnostic *RuffDiagnostic, reportType string, reportEngine string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   diagnostic.Code,
+		Description: diagnostic.Message,
+		Location: ReportLocation{
+			Path: fingerprintPath(diagnostic.Filename),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   diagnostic.Location.Row,
+					Column: diagnostic.Location.Column,
+				},
+				End: ReportLocationPositionsData{
+					Line:   diagnostic.Location.Row,
+					Column: diagnostic.Location.Column,
+				},
+			},
+		},
+		Content: ReportContent{
+			Body: diagnostic.body(),
+		},
+	}
+
+	if diagnostic.EndLocation != nil {
+		newReport.Location.Positions.End = ReportLocationPositionsData{
+			Line:   diagnostic.EndLocation.Row,
+			Column: diagnostic.EndLocation.Column,
+		}
+	}
+
+	// Syntax errors have no code
+	severity := SeverityCritical
+	if diagnostic.Code != "" {
+		severity = pythonCodeLookup(pythonCodeSeverity, diagnostic.Code)
+	}
+	if severity == "" {
+		severity = SeverityMinor
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(severity)
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	if category := pythonCodeLookup(pythonCodeCategory, diagnostic.Code); category != "" {
+		newReport.Categories = []string{category}
+	} else if diagnostic.Code == "" {
+		newReport.Categories = []string{BugRisk}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
+
+// body tells whether the diagnostic is fixed by ruff check --fix and links the documentation of the rule
+func (diagnostic *RuffDiagnostic) body() string {
+	var body string
+
+	if diagnostic.Fix != nil {
+		switch diagnostic.Fix.Applicability {
+		case "safe":
+			body = "Fixable with `ruff check --fix`"
+		case "unsafe":
+			body = "Fixable with `ruff check --fix --unsafe-fixes`"
+		}
+		if diagnostic.Fix.Message != "" && body != "" {
+			body += ": " + diagnostic.Fix.Message
+		}
+	}
+
+	if diagnostic.URL != "" {
+		if body != "" {
+			body += "\n\n"
+		}
+		body += diagnostic.URL
+	}
+
+	return body
+}
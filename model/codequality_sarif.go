@@ -0,0 +1,442 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatSarif, NewSarifParser)
+}
+
+// SarifLog represents a SARIF 2.1.0 log file.
+// {"version": "2.1.0", "$schema": "...", "runs": [...]}
+//
+// References:
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type SarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema,omitempty"`
+	Runs    []*SarifRun `json:"runs"`
+}
+
+// SarifRun represents a single invocation of an analysis tool
+type SarifRun struct {
+	Tool               SarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]SarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []*SarifResult                   `json:"results"`
+}
+
+// SarifTool represents the tool that produced a run
+type SarifTool struct {
+	Driver     SarifToolComponent   `json:"driver"`
+	Extensions []SarifToolComponent `json:"extensions,omitempty"`
+}
+
+// SarifToolComponent represents the driver or an extension (plugin) of a tool
+type SarifToolComponent struct {
+	Name  string       `json:"name"`
+	Rules []*SarifRule `json:"rules,omitempty"`
+}
+
+// SarifRule represents a reportingDescriptor describing a rule
+type SarifRule struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *SarifMessage           `json:"shortDescription,omitempty"`
+	FullDescription      *SarifMessage           `json:"fullDescription,omitempty"`
+	Help                 *SarifMessage           `json:"help,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+	DefaultConfiguration *SarifRuleConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *SarifPropertyBag       `json:"properties,omitempty"`
+}
+
+// SarifRuleConfiguration represents the default configuration of a rule
+type SarifRuleConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+// SarifPropertyBag represents the well known properties attached to rules
+type SarifPropertyBag struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+// SarifMessage represents a message with plain text and/or markdown
+type SarifMessage struct {
+	Text     string `json:"text,omitempty"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// SarifResult represents a single issue reported by a tool
+type SarifResult struct {
+	RuleID           string              `json:"ruleId,omitempty"`
+	RuleIndex        *int                `json:"ruleIndex,omitempty"`
+	Rule             *SarifRuleReference `json:"rule,omitempty"`
+	Kind             string              `json:"kind,omitempty"`
+	Level            string              `json:"level,omitempty"`
+	Message          SarifMessage        `json:"message"`
+	Locations        []*SarifLocation    `json:"locations,omitempty"`
+	RelatedLocations []*SarifLocation    `json:"relatedLocations,omitempty"`
+	Suppressions     []*SarifSuppression `json:"suppressions,omitempty"`
+}
+
+// SarifRuleReference represents a reportingDescriptorReference to a rule
+type SarifRuleReference struct {
+	ID            string                       `json:"id,omitempty"`
+	Index         *int                         `json:"index,omitempty"`
+	ToolComponent *SarifToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+// SarifToolComponentReference represents a reference to the driver or an extension
+type SarifToolComponentReference struct {
+	Name  string `json:"name,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// SarifSuppression represents a suppression applied to a result
+type SarifSuppression struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status,omitempty"`
+}
+
+// SarifLocation represents a location of a result
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *SarifMessage          `json:"message,omitempty"`
+}
+
+// SarifPhysicalLocation represents an artifact and a region within it
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+// SarifArtifactLocation represents the location of a file
+type SarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SarifRegion represents a region within a file
+type SarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SarifParser reads SARIF 2.1.0 logs
+type SarifParser struct {
+	options ParserOptions
+}
+
+// NewSarifParser creates a SARIF parser
+func NewSarifParser(options ParserOptions) Parser {
+	return &SarifParser{options: options}
+}
+
+func (p *SarifParser) Name() string {
+	return ReportFormatSarif
+}
+
+func (p *SarifParser) Detect(data []byte) bool {
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"runs"`))
+}
+
+func (p *SarifParser) Parse(in io.Reader) ([]*Report, error) {
+	var sarifLog SarifLog
+	if err := json.NewDecoder(in).Decode(&sarifLog); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a SARIF 2.1.0 log")
+	}
+
+	return NewReportsFromSarif(&sarifLog, p.options.ReportType, p.options.ReportEngine), nil
+}
+
+// NewReportsFromSarif converts every result of every run into a Report.
+// When reportEngine is empty the name of the tool driver is used as engine.
+func NewReportsFromSarif(sarifLog *SarifLog, reportType string, reportEngine string) []*Report {
+	reports := make([]*Report, 0)
+
+	for _, run := range sarifLog.Runs {
+		engine := reportEngine
+		if engine == "" {
+			engine = strings.ToLower(run.Tool.Driver.Name)
+		}
+
+		for _, result := range run.Results {
+			if result.isSuppressed() || result.Kind == "pass" || result.Kind == "notApplicable" {
+				continue
+			}
+
+			reports = append(reports, newReportFromSarifResult(run, result, reportType, engine))
+		}
+	}
+
+	return reports
+}
+
+func newReportFromSarifResult(run *SarifRun, result *SarifResult, reportType string, reportEngine string) *Report {
+	rule := run.rule(result)
+
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   result.ruleID(rule),
+		Description: result.Message.Text,
+	}
+
+	if newReport.Description == "" {
+		newReport.Description = result.Message.Markdown
+	}
+
+	if len(result.Locations) > 0 {
+		newReport.Location = result.Locations[0].toReportLocation(run)
+	}
+
+	for _, relatedLocation := range result.RelatedLocations {
+		if relatedLocation.PhysicalLocation == nil {
+			continue
+		}
+		newReport.OtherLocations = append(newReport.OtherLocations, relatedLocation.toReportLocation(run))
+	}
+
+	if rule != nil {
+		newReport.Content.Body = rule.body()
+		if newReport.Description == "" && rule.ShortDescription != nil {
+			newReport.Description = rule.ShortDescription.Text
+		}
+	}
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(sarifSeverity(result, rule))
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	if rule != nil && rule.hasTag("security") {
+		newReport.Categories = []string{Security}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
+
+// rule resolves the rule descriptor referenced by a result, if any
+func (run *SarifRun) rule(result *SarifResult) *SarifRule {
+	component := &run.Tool.Driver
+	index := result.RuleIndex
+	ruleID := result.RuleID
+
+	if result.Rule != nil {
+		if result.Rule.Index != nil {
+			index = result.Rule.Index
+		}
+		if result.Rule.ID != "" {
+			ruleID = result.Rule.ID
+		}
+		if result.Rule.ToolComponent != nil && result.Rule.ToolComponent.Index != nil {
+			extensionIndex := *result.Rule.ToolComponent.Index
+			if extensionIndex < 0 || extensionIndex >= len(run.Tool.Extensions) {
+				return nil
+			}
+			component = &run.Tool.Extensions[extensionIndex]
+		}
+	}
+
+	if index != nil && *index >= 0 && *index < len(component.Rules) {
+		return component.Rules[*index]
+	}
+
+	for _, rule := range component.Rules {
+		if rule.ID == ruleID {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func (result *SarifResult) ruleID(rule *SarifRule) string {
+	if result.RuleID != "" {
+		return result.RuleID
+	}
+
+	if result.Rule != nil && result.Rule.ID != "" {
+		return result.Rule.ID
+	}
+
+	if rule != nil {
+		return rule.ID
+	}
+
+	return ""
+}
+
+func (result *SarifResult) isSuppressed() bool {
+	for _, suppression := range result.Suppressions {
+		if suppression.Status == "" || suppression.Status == "accepted" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule *SarifRule) body() string {
+	var body string
+
+	switch {
+	case rule.Help != nil && rule.Help.Markdown != "":
+		body = rule.Help.Markdown
+	case rule.Help != nil && rule.Help.Text != "":
+		body = rule.Help.Text
+	case rule.FullDescription != nil && rule.FullDescription.Markdown != "":
+		body = rule.FullDescription.Markdown
+	case rule.FullDescription != nil:
+		body = rule.FullDescription.Text
+	}
+
+	if rule.HelpURI != "" && !strings.Contains(body, rule.HelpURI) {
+		if body != "" {
+			body += "\n\n"
+		}
+		body += rule.HelpURI
+	}
+
+	return body
+}
+
+func (rule *SarifRule) hasTag(tag string) bool {
+	if rule.Properties == nil {
+		return false
+	}
+
+	for _, ruleTag := range rule.Properties.Tags {
+		if strings.EqualFold(ruleTag, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (location *SarifLocation) toReportLocation(run *SarifRun) ReportLocation {
+	reportLocation := ReportLocation{}
+
+	if location.PhysicalLocation == nil {
+		return reportLocation
+	}
+
+	reportLocation.Path = sarifPath(run.resolveURI(location.PhysicalLocation.ArtifactLocation))
+
+	region := location.PhysicalLocation.Region
+	if region == nil {
+		return reportLocation
+	}
+
+	reportLocation.Positions.Begin = ReportLocationPositionsData{
+		Line:   region.StartLine,
+		Column: region.StartColumn,
+	}
+	reportLocation.Positions.End = ReportLocationPositionsData{
+		Line:   region.EndLine,
+		Column: region.EndColumn,
+	}
+
+	// SARIF defaults the region to whole lines starting at its start line
+	if reportLocation.Positions.Begin.Column == 0 {
+		reportLocation.Positions.Begin.Column = 1
+	}
+
+	if reportLocation.Positions.End.Line == 0 {
+		reportLocation.Positions.End.Line = region.StartLine
+	}
+
+	if reportLocation.Positions.End.Column == 0 {
+		reportLocation.Positions.End.Column = reportLocation.Positions.Begin.Column
+	}
+
+	return reportLocation
+}
+
+// resolveURI returns the URI of an artifact resolved against the originalUriBaseIds of the run,
+// which may themselves be relative to another base
+func (run *SarifRun) resolveURI(artifactLocation SarifArtifactLocation) string {
+	uri := artifactLocation.URI
+	baseID := artifactLocation.URIBaseID
+
+	// A base can't refer to itself, the depth guards against cycles
+	for depth := 0; baseID != "" && depth < len(run.OriginalURIBaseIDs); depth++ {
+		base, exists := run.OriginalURIBaseIDs[baseID]
+		if !exists {
+			break
+		}
+
+		relURI, err := url.Parse(uri)
+		if err != nil || relURI.IsAbs() || strings.HasPrefix(uri, "/") {
+			break
+		}
+
+		// Bases end with a slash, a relative one is resolved against its own base next
+		if base.URI != "" {
+			uri = strings.TrimSuffix(base.URI, "/") + "/" + uri
+		}
+		baseID = base.URIBaseID
+	}
+
+	return uri
+}
+
+// sarifPath converts an artifact URI into a file path relative to the working directory
+func sarifPath(uri string) string {
+	parsedURI, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	if parsedURI.Path != "" && (parsedURI.Scheme == "file" || parsedURI.Scheme == "") {
+		return fingerprintPath(parsedURI.Path)
+	}
+
+	return uri
+}
+
+// sarifSeverity maps the result level, or the CVSS score of security rules, to a severity
+func sarifSeverity(result *SarifResult, rule *SarifRule) string {
+	if rule != nil && rule.Properties != nil && rule.Properties.SecuritySeverity != "" {
+		score, err := strconv.ParseFloat(rule.Properties.SecuritySeverity, 64)
+		if err == nil {
+			switch {
+			case score >= 9.0:
+				return SeverityCritical
+			case score >= 7.0:
+				return SeverityMajor
+			case score >= 4.0:
+				return SeverityMinor
+			default:
+				return SeverityInfo
+			}
+		}
+	}
+
+	level := result.Level
+	if level == "" && rule != nil && rule.DefaultConfiguration != nil {
+		level = rule.DefaultConfiguration.Level
+	}
+
+	switch level {
+	case "error":
+		return SeverityMajor
+	case "note", "none":
+		return SeverityInfo
+	default:
+		return SeverityMinor
+	}
+}
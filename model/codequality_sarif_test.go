@@ -0,0 +1,112 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	sarifReport = `{
+	"version": "2.1.0",
+	"runs": [{
+		"tool": {"driver": {"name": "ESLint", "rules": [{"id": "no-eval", "help": {"text": "Disallow eval()"}}]}},
+		"results": [{
+			"ruleId": "no-eval",
+			"ruleIndex": 0,
+			"level": "error",
+			"message": {"text": "eval can be harmful."},
+			"locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/index.ts"}, "region": {"startLine": 3, "startColumn": 5, "endLine": 4, "endColumn": 9}}}],
+			"relatedLocations": [{"physicalLocation": {"artifactLocation": {"uri": "src/other.ts"}, "region": {"startLine": 7}}}]
+		}, {
+			"ruleId": "no-eval",
+			"message": {"text": "suppressed"},
+			"suppressions": [{"kind": "inSource"}]
+		}]
+	}]
+}`
+
+	sarifBaseReport = `{
+	"version": "2.1.0",
+	"runs": [{
+		"tool": {"driver": {"name": "CodeQL"}},
+		"originalUriBaseIds": {
+			"SRCROOT": {"uri": "file:///work/repo/"},
+			"PKGROOT": {"uri": "pkg/", "uriBaseId": "SRCROOT"}
+		},
+		"results": [{
+			"ruleId": "go/path-injection",
+			"message": {"text": "This path depends on a user-provided value."},
+			"locations": [{"physicalLocation": {"artifactLocation": {"uri": "server/files.go", "uriBaseId": "PKGROOT"}, "region": {"startLine": 12}}}]
+		}, {
+			"ruleId": "go/path-injection",
+			"message": {"text": "This path depends on a user-provided value."},
+			"locations": [{"physicalLocation": {"artifactLocation": {"uri": "%[1]s"}, "region": {"startLine": 20}}}]
+		}]
+	}]
+}`
+)
+
+func TestSarifParser(t *testing.T) {
+	parser, _ := NewParser(ReportFormatSarif, ParserOptions{ReportType: ReportTypeIssue})
+
+	reports, err := parser.Parse(strings.NewReader(sarifReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+
+	r := reports[0]
+	if r.EngineName != ReportEngineEslint || r.CheckName != "no-eval" || r.Severity != SeverityMajor {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r.Categories[0] != Security {
+		t.Errorf("expected category %s, got %v", Security, r.Categories)
+	}
+
+	if r.Content.Body != "Disallow eval()" {
+		t.Errorf("unexpected body %q", r.Content.Body)
+	}
+
+	if r.Location.Positions.End.Line != 4 || r.Location.Positions.End.Column != 9 {
+		t.Errorf("unexpected end position %+v", r.Location.Positions.End)
+	}
+
+	if len(r.OtherLocations) != 1 || r.OtherLocations[0].(ReportLocation).Path != "src/other.ts" {
+		t.Errorf("unexpected other locations %+v", r.OtherLocations)
+	}
+}
+
+func TestSarifParserPaths(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Absolute file URIs within the working directory are made relative to it
+	fileURI := "file://" + filepath.ToSlash(filepath.Join(wd, "cmd", "main.go"))
+	data := strings.Replace(sarifBaseReport, "%[1]s", fileURI, 1)
+
+	parser, _ := NewParser(ReportFormatSarif, ParserOptions{ReportType: ReportTypeIssue})
+	reports, err := parser.Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %d", len(reports))
+	}
+
+	if path := reports[0].Location.Path; path != "/work/repo/pkg/server/files.go" {
+		t.Errorf("expected the path resolved against the uri base ids, got %s", path)
+	}
+
+	if path := reports[1].Location.Path; path != "cmd/main.go" {
+		t.Errorf("expected a path relative to the working directory, got %s", path)
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severities of the security scanners, from the lowest to the highest
+var securitySeverityLevels = []string{SeverityInfo, SeverityMinor, SeverityMajor, SeverityCritical}
+
+var securitySeverity = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"warning":  2,
+	"high":     3,
+	"error":    3,
+	"critical": 3,
+}
+
+var cweIDRe = regexp.MustCompile(`(?i)^(?:CWE-)?([0-9]+)`)
+
+// securityFindingSeverity maps the severity of a finding, one level lower when the scanner has a low confidence in it.
+// Unknown severities are minor.
+func securityFindingSeverity(severity string, confidence string) string {
+	level, exists := securitySeverity[strings.ToLower(severity)]
+	if !exists {
+		level = 1
+	}
+
+	if strings.EqualFold(confidence, "low") && level > 0 {
+		level--
+	}
+
+	return securitySeverityLevels[level]
+}
+
+// cweReference returns a line naming a CWE and linking to its definition, the CWE is either an id
+// or a title such as "CWE-78: Improper Neutralization of Special Elements used in an OS Command"
+func cweReference(cwe string) string {
+	m := cweIDRe.FindStringSubmatch(strings.TrimSpace(cwe))
+	if m == nil || m[1] == "0" {
+		return ""
+	}
+
+	title := strings.TrimSpace(cwe)
+	if title == m[1] {
+		title = "CWE-" + m[1]
+	}
+
+	return fmt.Sprintf("%s (https://cwe.mitre.org/data/definitions/%s.html)", title, m[1])
+}
+
+// securityBody joins the CWE references and the links to the documentation of a finding
+func securityBody(cwes []string, links []string) string {
+	lines := make([]string, 0)
+
+	for _, cwe := range cwes {
+		if reference := cweReference(cwe); reference != "" {
+			lines = append(lines, reference)
+		}
+	}
+
+	for _, link := range links {
+		if link != "" {
+			lines = append(lines, link)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
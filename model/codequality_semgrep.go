@@ -0,0 +1,169 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+func init() {
+	RegisterParser(ReportFormatSemgrep, NewSemgrepParser)
+}
+
+// SemgrepResult represents the JSON output of semgrep (--json).
+// {"results": [{"check_id": "...", "path": "app.py", "start": {...}, "end": {...}, "extra": {...}}], "errors": [], "paths": {...}}
+//
+// References:
+// https://semgrep.dev/docs/cli-reference
+// https://github.com/semgrep/semgrep-interfaces/blob/main/semgrep_output_v1.atd
+type SemgrepResult struct {
+	Results []*SemgrepFinding `json:"results"`
+}
+
+// SemgrepFinding represents a match of a rule, columns are 1 based and the end column is exclusive
+type SemgrepFinding struct {
+	CheckID string          `json:"check_id"`
+	Path    string          `json:"path"`
+	Start   SemgrepPosition `json:"start"`
+	End     SemgrepPosition `json:"end"`
+	Extra   SemgrepExtra    `json:"extra"`
+}
+
+// SemgrepPosition represents a position of a finding
+type SemgrepPosition struct {
+	Line int `json:"line"`
+	Col  int `json:"col"`
+}
+
+// SemgrepExtra represents the message, severity and rule metadata of a finding
+type SemgrepExtra struct {
+	Message   string          `json:"message"`
+	Severity  string          `json:"severity"`
+	Metadata  SemgrepMetadata `json:"metadata"`
+	IsIgnored bool            `json:"is_ignored"`
+}
+
+// SemgrepMetadata represents the metadata of a rule, rules are free to use a string or a list for most fields
+type SemgrepMetadata struct {
+	Category   string         `json:"category"`
+	Confidence string         `json:"confidence"`
+	Cwe        semgrepStrings `json:"cwe"`
+	References semgrepStrings `json:"references"`
+	Source     string         `json:"source"`
+}
+
+type semgrepStrings []string
+
+func (s *semgrepStrings) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*s = semgrepStrings{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = values
+
+	return nil
+}
+
+// Categories of the metadata of rules other than security ones
+var semgrepCategory = map[string]string{
+	"best-practice":   Style,
+	"correctness":     BugRisk,
+	"maintainability": Clarity,
+	"performance":     Complexity,
+	"portability":     Compatibility,
+	"security":        Security,
+}
+
+// SemgrepParser reads semgrep JSON reports
+type SemgrepParser struct {
+	options ParserOptions
+}
+
+// NewSemgrepParser creates a semgrep JSON parser
+func NewSemgrepParser(options ParserOptions) Parser {
+	return &SemgrepParser{options: options}
+}
+
+func (p *SemgrepParser) Name() string {
+	return ReportFormatSemgrep
+}
+
+func (p *SemgrepParser) Detect(data []byte) bool {
+	// SARIF logs of semgrep also list "results", within "runs"
+	trimmedData := bytes.TrimSpace(data)
+	return bytes.HasPrefix(trimmedData, []byte("{")) && bytes.Contains(trimmedData, []byte(`"results"`)) &&
+		!bytes.Contains(trimmedData, []byte(`"runs"`)) &&
+		(bytes.Contains(trimmedData, []byte(`"check_id"`)) || bytes.Contains(trimmedData, []byte(`"paths"`)))
+}
+
+func (p *SemgrepParser) Parse(in io.Reader) ([]*Report, error) {
+	var result SemgrepResult
+	if err := json.NewDecoder(in).Decode(&result); err != nil {
+		return nil, errors.New("could not parse the provided file, it must be a semgrep json report")
+	}
+
+	engine := p.options.ReportEngine
+	if engine == "" {
+		engine = ReportFormatSemgrep
+	}
+
+	// Findings silenced by nosemgrep comments are only listed with --disable-nosem
+	reports := make([]*Report, 0, len(result.Results))
+	for _, finding := range result.Results {
+		if finding.Extra.IsIgnored {
+			continue
+		}
+
+		reports = append(reports, NewReportFromSemgrep(finding, p.options.ReportType, engine))
+	}
+
+	return reports, nil
+}
+
+// NewReportFromSemgrep converts a finding into a Report, findings of rules without a category are security ones
+func NewReportFromSemgrep(finding *SemgrepFinding, reportType string, reportEngine string) *Report {
+	newReport := &Report{
+		EngineName:  reportEngine,
+		Type:        reportType,
+		CheckName:   finding.CheckID,
+		Description: finding.Extra.Message,
+		Location: ReportLocation{
+			Path: fingerprintPath(finding.Path),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{
+					Line:   finding.Start.Line,
+					Column: finding.Start.Col,
+				},
+				End: ReportLocationPositionsData{
+					Line:   finding.End.Line,
+					Column: finding.End.Col,
+				},
+			},
+		},
+	}
+
+	metadata := finding.Extra.Metadata
+	newReport.Content.Body = securityBody(metadata.Cwe, append([]string{metadata.Source}, metadata.References...))
+
+	newReport.SetDefaults()
+	newReport.SetSeverity(securityFindingSeverity(finding.Extra.Severity, metadata.Confidence))
+	newReport.SetCheckName()
+	newReport.SetCategories()
+
+	newReport.Categories = []string{Security}
+	if category := semgrepCategory[strings.ToLower(metadata.Category)]; category != "" {
+		newReport.Categories = []string{category}
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
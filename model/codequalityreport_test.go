@@ -47,3 +47,157 @@ func TestSetCategories(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCategoriesGolangci(t *testing.T) {
+	for _, test := range []struct {
+		source   string
+		message  string
+		category string
+	}{
+		{"errcheck", "Error return value of `f.Close` is not checked", BugRisk},
+		{"gocyclo", "cyclomatic complexity 31 of func `main` is high (> 30)", Complexity},
+		{"gosec", "G104: Errors unhandled.", Security},
+		{"staticcheck", "SA1019: ioutil.ReadAll has been deprecated", Compatibility},
+		{"staticcheck", "SA4017: Sprintf doesn't have side effects", BugRisk},
+		{"staticcheck", "S1002: should omit comparison to bool constant", Clarity},
+		{"stylecheck", "ST1005: error strings should not be capitalized", Style},
+		{"revive", "cognitive-complexity: function main has cognitive complexity 12", Complexity},
+		{"revive", "exported: exported function Parse should have comment or be unexported", Style},
+		{"misspell", "range: `colour` is a misspelling of `color`", Style},
+		{"unknown", "message", Style},
+	} {
+		r := NewReportFromCheckstyle(&CheckStyleError{Line: 1, Message: test.message, Source: test.source}, ReportTypeIssue, ReportEngineGolangci, "main.go")
+
+		if len(r.Categories) != 1 || r.Categories[0] != test.category {
+			t.Errorf("%s %q: expected category %s, got %v", test.source, test.message, test.category, r.Categories)
+		}
+	}
+}
+
+func newFingerprintReport(line int, description string) *Report {
+	return NewReportFromCheckstyle(&CheckStyleError{
+		Column:   5,
+		Line:     line,
+		Message:  description,
+		Severity: "warning",
+		Source:   reportCheckName,
+	}, ReportTypeIssue, ReportEngineEslint, reportFileName)
+}
+
+func TestComputeFingerprintEveryReport(t *testing.T) {
+	r := NewReportFromCheckstyle(&CheckStyleError{Line: 1, Message: "Missing semicolon.", Source: "semi"}, ReportTypeIssue, "golangci-lint", reportFileName)
+
+	if r.Fingerprint == "" {
+		t.Error("expected a fingerprint for a non eslint report")
+	}
+}
+
+func TestComputeFingerprintsLineShift(t *testing.T) {
+	before := []*Report{
+		newFingerprintReport(10, "'a' is defined but never used."),
+		newFingerprintReport(20, "'b' is defined but never used."),
+	}
+	// Lines inserted above both issues
+	after := []*Report{
+		newFingerprintReport(25, "'b' is defined but never used."),
+		newFingerprintReport(15, "'a' is defined but never used."),
+	}
+
+	ComputeFingerprints(before)
+	ComputeFingerprints(after)
+
+	if before[0].Fingerprint != after[1].Fingerprint || before[1].Fingerprint != after[0].Fingerprint {
+		t.Error("expected fingerprints to survive a line shift")
+	}
+
+	if before[0].Fingerprint == before[1].Fingerprint {
+		t.Error("expected different issues to have different fingerprints")
+	}
+}
+
+func TestComputeFingerprintsOccurrences(t *testing.T) {
+	reports := []*Report{
+		newFingerprintReport(30, "Unexpected any. Specify a different type."),
+		newFingerprintReport(12, "Unexpected any. Specify a different type."),
+	}
+	ComputeFingerprints(reports)
+
+	if reports[0].Fingerprint == reports[1].Fingerprint {
+		t.Fatal("expected identical issues to have different fingerprints")
+	}
+
+	// Removing the second occurrence keeps the fingerprint of the first one
+	remaining := []*Report{newFingerprintReport(2, "Unexpected any. Specify a different type.")}
+	ComputeFingerprints(remaining)
+
+	if remaining[0].Fingerprint != reports[1].Fingerprint {
+		t.Error("expected the first occurrence to keep its fingerprint")
+	}
+}
+
+func TestComputeFingerprintsIgnoresNumbers(t *testing.T) {
+	reports := []*Report{newFingerprintReport(10, "'x' is already declared on line 4.")}
+	shifted := []*Report{newFingerprintReport(12, "'x' is already declared on line 6.")}
+	ComputeFingerprints(reports)
+	ComputeFingerprints(shifted)
+
+	if reports[0].Fingerprint != shifted[0].Fingerprint {
+		t.Error("expected line numbers in messages to be ignored")
+	}
+}
+
+func TestCompareBaseline(t *testing.T) {
+	baseline := []*Report{
+		newFingerprintReport(10, "'a' is defined but never used."),
+		newFingerprintReport(20, "'b' is defined but never used."),
+	}
+	ComputeFingerprints(baseline)
+
+	reports := []*Report{
+		newFingerprintReport(12, "'a' is defined but never used."),
+		newFingerprintReport(30, "'c' is defined but never used."),
+	}
+	ComputeFingerprints(reports)
+
+	comparison := CompareBaseline(reports, baseline)
+
+	if len(comparison.New) != 1 || comparison.New[0] != reports[1] {
+		t.Errorf("expected 1 new issue, got %d", len(comparison.New))
+	}
+
+	if len(comparison.Unchanged) != 1 || comparison.Unchanged[0] != reports[0] {
+		t.Errorf("expected 1 unchanged issue, got %d", len(comparison.Unchanged))
+	}
+
+	if len(comparison.Fixed) != 1 || comparison.Fixed[0] != baseline[1] {
+		t.Errorf("expected 1 fixed issue, got %d", len(comparison.Fixed))
+	}
+}
+
+func TestQualityGate(t *testing.T) {
+	reports := []*Report{
+		newFingerprintReport(10, "'a' is defined but never used."),
+		newFingerprintReport(20, "'b' is defined but never used."),
+	}
+
+	gate, err := NewQualityGate("", -1, map[string]int{"bug-risk": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := gate.Evaluate(reports); len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+
+	gate, _ = NewQualityGate("minor", 1, map[string]int{"Bug Risk": 1})
+	if violations := gate.Evaluate(reports); len(violations) != 3 {
+		t.Errorf("expected 3 violations, got %v", violations)
+	}
+
+	if _, err := NewQualityGate("fatal", -1, nil); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+
+	if _, err := NewQualityGate("", -1, map[string]int{"Performance": 0}); err == nil {
+		t.Error("expected an error for an unknown category")
+	}
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// lineBranches counts the branches of a line and how many of them were taken
+type lineBranches struct {
+	covered int64
+	valid   int64
+}
+
+// branches computes the branch coverage of the if, switch, select and for statements of a function
+// from the profile blocks of their bodies, and records it on the lines of the method.
+func (v *fileVisitor) branches(n *ast.FuncDecl, method *Method) {
+	if n.Body == nil {
+		return
+	}
+
+	branchesByLine := make(map[int]*lineBranches)
+	addBranch := func(node ast.Node, covered bool) {
+		line := v.fset.Position(node.Pos()).Line
+		if branchesByLine[line] == nil {
+			branchesByLine[line] = &lineBranches{}
+		}
+		branchesByLine[line].valid++
+		if covered {
+			branchesByLine[line].covered++
+		}
+	}
+
+	ast.Inspect(n.Body, func(node ast.Node) bool {
+		switch s := node.(type) {
+		case *ast.IfStmt:
+			taken := v.bodyCount(s, s.Body)
+			addBranch(s, taken > 0)
+
+			switch e := s.Else.(type) {
+			case *ast.BlockStmt:
+				addBranch(s, v.bodyCount(s, e) > 0)
+			case *ast.IfStmt:
+				addBranch(s, v.blockCount(e.Pos()) > 0)
+			default:
+				addBranch(s, v.fallthroughTaken(s, s.Body, taken, n.End()))
+			}
+		case *ast.ForStmt:
+			if s.Cond == nil {
+				// Infinite loops only leave through break or return
+				return true
+			}
+			addBranch(s, v.bodyCount(s, s.Body) > 0)
+			addBranch(s, v.loopExited(s, n.End()))
+		case *ast.RangeStmt:
+			addBranch(s, v.bodyCount(s, s.Body) > 0)
+			addBranch(s, v.loopExited(s, n.End()))
+		case *ast.SwitchStmt:
+			v.clauseBranches(s, s.Body, n.End(), addBranch)
+		case *ast.TypeSwitchStmt:
+			v.clauseBranches(s, s.Body, n.End(), addBranch)
+		case *ast.SelectStmt:
+			for _, clause := range s.Body.List {
+				clause := clause.(*ast.CommClause)
+				count, _ := v.nextBlockCount(clause.Colon, clause.End())
+				addBranch(s, count > 0)
+			}
+		}
+		return true
+	})
+
+	for _, line := range method.Lines {
+		if branches := branchesByLine[line.Number]; branches != nil {
+			line.SetBranches(branches.covered, branches.valid)
+		}
+	}
+}
+
+// clauseBranches adds a branch per case clause, plus the implicit default clause when there is none
+func (v *fileVisitor) clauseBranches(s ast.Stmt, body *ast.BlockStmt, funcEnd token.Pos, addBranch func(ast.Node, bool)) {
+	hasDefault := false
+	taken := 0
+	for _, stmt := range body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			hasDefault = true
+		}
+
+		// Empty clauses have no block, they are reported as not taken
+		count, _ := v.nextBlockCount(clause.Colon, clause.End())
+		taken += count
+		addBranch(s, count > 0)
+	}
+
+	if !hasDefault {
+		addBranch(s, v.fallthroughTaken(s, nil, taken, funcEnd))
+	}
+}
+
+// fallthroughTaken reports whether the implicit branch of a statement, e.g. an if without else, was taken.
+// In count mode the statement must have been reached more often than its explicit branches were taken.
+// In set mode this can only be told when no explicit branch was taken, or when the explicit branch
+// always leaves the function or loop and the code following the statement was reached.
+func (v *fileVisitor) fallthroughTaken(s ast.Stmt, body *ast.BlockStmt, taken int, funcEnd token.Pos) bool {
+	header := v.blockCount(s.Pos())
+	if header == 0 {
+		return false
+	}
+
+	if v.profile.Mode != "set" {
+		return header > taken
+	}
+
+	if taken == 0 {
+		return true
+	}
+
+	if body == nil || len(body.List) == 0 || !isTerminating(body.List[len(body.List)-1]) {
+		return false
+	}
+
+	count, found := v.nextBlockCount(s.End(), funcEnd)
+	return found && count > 0
+}
+
+// loopExited reports whether a loop was reached and the code following it, if any, was executed
+func (v *fileVisitor) loopExited(s ast.Stmt, funcEnd token.Pos) bool {
+	if v.blockCount(s.Pos()) == 0 {
+		return false
+	}
+
+	count, found := v.nextBlockCount(s.End(), funcEnd)
+	return !found || count > 0
+}
+
+// isTerminating reports whether a statement always leaves the current block
+func isTerminating(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "panic" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bodyCount returns the count of the first block of a body, an empty body runs whenever its statement does
+func (v *fileVisitor) bodyCount(s ast.Stmt, body *ast.BlockStmt) int {
+	if count, found := v.nextBlockCount(body.Lbrace, body.Rbrace); found {
+		return count
+	}
+	return v.blockCount(s.Pos())
+}
+
+// blockCount returns the count of the profile block containing the position
+func (v *fileVisitor) blockCount(pos token.Pos) int {
+	p := v.fset.Position(pos)
+
+	count := 0
+	for _, b := range v.profile.Blocks {
+		if b.StartLine > p.Line || (b.StartLine == p.Line && b.StartCol > p.Column) {
+			break
+		}
+		if b.EndLine > p.Line || (b.EndLine == p.Line && b.EndCol > p.Column) {
+			count = b.Count
+		}
+	}
+	return count
+}
+
+// nextBlockCount returns the count of the first profile block starting at or after pos and before end
+func (v *fileVisitor) nextBlockCount(pos token.Pos, end token.Pos) (int, bool) {
+	p := v.fset.Position(pos)
+	e := v.fset.Position(end)
+
+	for _, b := range v.profile.Blocks {
+		if b.StartLine < p.Line || (b.StartLine == p.Line && b.StartCol < p.Column) {
+			continue
+		}
+		if b.StartLine > e.Line || (b.StartLine == e.Line && b.StartCol >= e.Column) {
+			return 0, false
+		}
+		return b.Count, true
+	}
+	return 0, false
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+)
+
+const branchProfile = `mode: set
+branch.go:4.2,4.11 1 1
+branch.go:5.3,6.1 1 1
+branch.go:6.9,6.18 1 0
+branch.go:7.3,8.1 1 0
+branch.go:9.2,9.10 1 0
+branch.go:13.2,13.12 1 1
+branch.go:14.3,15.1 1 0
+branch.go:16.2,16.10 1 1
+branch.go:20.2,20.18 1 1
+branch.go:22.3,22.15 1 1
+branch.go:24.3,24.18 1 0
+branch.go:26.2,26.16 1 0
+branch.go:30.2,31.23 2 1
+branch.go:32.3,33.1 1 0
+branch.go:34.2,34.10 1 1
+`
+
+func visitTestdata(t *testing.T, fileName string, profile string) *Package {
+	profiles, err := ParseProfiles(strings.NewReader(profile), &Ignore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, fileName, data, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg := &Package{Classes: []*Class{}}
+	pragmas := coveragePragmas(fset, parsed)
+	ast.Walk(&fileVisitor{
+		fset:     fset,
+		fileName: fileName,
+		fileData: data,
+		classes:  make(map[string]*Class),
+		pkg:      pkg,
+		profile:  profiles[0],
+		pragmas:  pragmas,
+		ignored:  ignoredRanges(pragmas),
+	}, parsed)
+
+	return pkg
+}
+
+func TestBranchCoverage(t *testing.T) {
+	pkg := visitTestdata(t, "testdata/branch/branch.go", branchProfile)
+
+	expected := map[int]string{
+		4:  "50% (1/2)",
+		6:  "0% (0/2)",
+		13: "50% (1/2)",
+		20: "33% (1/3)",
+		31: "50% (1/2)",
+	}
+
+	for _, line := range pkg.Classes[0].Lines {
+		if line.ConditionCoverage != expected[line.Number] {
+			t.Errorf("line %d: expected %q, got %q", line.Number, expected[line.Number], line.ConditionCoverage)
+		}
+	}
+
+	if numBranches := pkg.NumBranches(); numBranches != 11 {
+		t.Errorf("expected 11 branches, got %d", numBranches)
+	}
+
+	if numBranchesCovered := pkg.NumBranchesCovered(); numBranchesCovered != 4 {
+		t.Errorf("expected 4 covered branches, got %d", numBranchesCovered)
+	}
+}
@@ -50,6 +50,7 @@ type Class struct {
 	Complexity float32   `xml:"complexity,attr"`
 	Methods    []*Method `xml:"methods>method"`
 	Lines      Lines     `xml:"lines>line"`
+	Source     string    `xml:"-"`
 }
 
 type Method struct {
@@ -59,11 +60,29 @@ type Method struct {
 	BranchRate float32 `xml:"branch-rate,attr"`
 	Complexity float32 `xml:"complexity,attr"`
 	Lines      Lines   `xml:"lines>line"`
+	StartLine  int     `xml:"-"`
+	EndLine    int     `xml:"-"`
 }
 
 type Line struct {
-	Number int   `xml:"number,attr"`
-	Hits   int64 `xml:"hits,attr"`
+	Number            int    `xml:"number,attr"`
+	Hits              int64  `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr,omitempty"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+	Branches          int64  `xml:"-"`
+	BranchesCovered   int64  `xml:"-"`
+}
+
+// SetBranches records the number of branches of the line and how many of them were taken
+func (line *Line) SetBranches(branchesCovered int64, branches int64) {
+	if branches == 0 {
+		return
+	}
+
+	line.Branch = true
+	line.Branches = branches
+	line.BranchesCovered = branchesCovered
+	line.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", 100*branchesCovered/branches, branchesCovered, branches)
 }
 
 // Lines is a slice of Line pointers, with some convenience methods
@@ -90,6 +109,35 @@ func (lines Lines) NumLinesWithHits() (numLinesWithHits int64) {
 	return numLinesWithHits
 }
 
+// BranchHitRate returns a float32 from 0.0 to 1.0 representing what fraction of branches
+// were taken, lines without branches have a rate of 0.0
+func (lines Lines) BranchHitRate() float32 {
+	return branchRate(lines.NumBranchesCovered(), lines.NumBranches())
+}
+
+// NumBranches returns the number of branches
+func (lines Lines) NumBranches() (numBranches int64) {
+	for _, line := range lines {
+		numBranches += line.Branches
+	}
+	return numBranches
+}
+
+// NumBranchesCovered returns the number of branches taken at least once
+func (lines Lines) NumBranchesCovered() (numBranchesCovered int64) {
+	for _, line := range lines {
+		numBranchesCovered += line.BranchesCovered
+	}
+	return numBranchesCovered
+}
+
+func branchRate(numBranchesCovered int64, numBranches int64) float32 {
+	if numBranches == 0 {
+		return 0
+	}
+	return float32(numBranchesCovered) / float32(numBranches)
+}
+
 // AddOrUpdateLine adds a line if it is a different line than the last line recorded.
 // If it's the same line as the last line recorded then we update the hits down
 // if the new hits is less; otherwise just leave it as-is
@@ -122,26 +170,46 @@ func (method Method) NumLinesWithHits() int64 {
 	return method.Lines.NumLinesWithHits()
 }
 
+// Path returns the path of the file of the class relative to the working directory,
+// or its file name relative to its source when the source is not known
+func (class Class) Path() string {
+	if class.Source == "" {
+		return class.Filename
+	}
+
+	return fingerprintPath(filepath.Join(class.Source, filepath.FromSlash(class.Filename)))
+}
+
 // HitRate returns a float32 from 0.0 to 1.0 representing what fraction of lines
 // have hits
 func (class Class) HitRate() float32 {
 	return float32(class.NumLinesWithHits()) / float32(class.NumLines())
 }
 
-// NumLines returns the number of lines
-func (class Class) NumLines() (numLines int64) {
-	for _, method := range class.Methods {
-		numLines += method.NumLines()
-	}
-	return numLines
+// NumLines returns the number of lines, including the ones outside of methods
+func (class Class) NumLines() int64 {
+	return class.Lines.NumLines()
 }
 
 // NumLinesWithHits returns the number of lines with a hit count > 0
-func (class Class) NumLinesWithHits() (numLinesWithHits int64) {
-	for _, method := range class.Methods {
-		numLinesWithHits += method.NumLinesWithHits()
-	}
-	return numLinesWithHits
+func (class Class) NumLinesWithHits() int64 {
+	return class.Lines.NumLinesWithHits()
+}
+
+// BranchHitRate returns a float32 from 0.0 to 1.0 representing what fraction of branches
+// were taken
+func (class Class) BranchHitRate() float32 {
+	return class.Lines.BranchHitRate()
+}
+
+// NumBranches returns the number of branches
+func (class Class) NumBranches() int64 {
+	return class.Lines.NumBranches()
+}
+
+// NumBranchesCovered returns the number of branches taken at least once
+func (class Class) NumBranchesCovered() int64 {
+	return class.Lines.NumBranchesCovered()
 }
 
 // HitRate returns a float32 from 0.0 to 1.0 representing what fraction of lines
@@ -166,6 +234,28 @@ func (pkg Package) NumLinesWithHits() (numLinesWithHits int64) {
 	return numLinesWithHits
 }
 
+// BranchHitRate returns a float32 from 0.0 to 1.0 representing what fraction of branches
+// were taken
+func (pkg Package) BranchHitRate() float32 {
+	return branchRate(pkg.NumBranchesCovered(), pkg.NumBranches())
+}
+
+// NumBranches returns the number of branches
+func (pkg Package) NumBranches() (numBranches int64) {
+	for _, class := range pkg.Classes {
+		numBranches += class.NumBranches()
+	}
+	return numBranches
+}
+
+// NumBranchesCovered returns the number of branches taken at least once
+func (pkg Package) NumBranchesCovered() (numBranchesCovered int64) {
+	for _, class := range pkg.Classes {
+		numBranchesCovered += class.NumBranchesCovered()
+	}
+	return numBranchesCovered
+}
+
 // HitRate returns a float32 from 0.0 to 1.0 representing what fraction of lines
 // have hits
 func (cov Coverage) HitRate() float32 {
@@ -188,6 +278,28 @@ func (cov Coverage) NumLinesWithHits() (numLinesWithHits int64) {
 	return numLinesWithHits
 }
 
+// BranchHitRate returns a float32 from 0.0 to 1.0 representing what fraction of branches
+// were taken
+func (cov Coverage) BranchHitRate() float32 {
+	return branchRate(cov.NumBranchesCovered(), cov.NumBranches())
+}
+
+// NumBranches returns the number of branches
+func (cov Coverage) NumBranches() (numBranches int64) {
+	for _, pkg := range cov.Packages {
+		numBranches += pkg.NumBranches()
+	}
+	return numBranches
+}
+
+// NumBranchesCovered returns the number of branches taken at least once
+func (cov Coverage) NumBranchesCovered() (numBranchesCovered int64) {
+	for _, pkg := range cov.Packages {
+		numBranchesCovered += pkg.NumBranchesCovered()
+	}
+	return numBranchesCovered
+}
+
 func AppendIfUnique(sources []*Source, dir string) []*Source {
 	for _, source := range sources {
 		if source.Path == dir {
@@ -225,29 +337,45 @@ func findAbsFilePath(pkg *packages.Package, profileName string) string {
 	return ""
 }
 
-func (cov *Coverage) ParseProfiles(profiles []*Profile, pkgMap map[string]*packages.Package, ignore *Ignore) error {
+// ParseProfiles builds the coverage report from the profiles, with a source per module.
+// File names are relative to the directory of their module and packages are named by import path,
+// which stays consistent when the profiles span the modules of a go.work workspace.
+// Classes are named after the receivers of the methods, or after the files when byFiles is set.
+func (cov *Coverage) ParseProfiles(profiles []*Profile, pkgMap map[string]*packages.Package, ignore *Ignore, byFiles bool) error {
 	cov.Packages = []*Package{}
 	for _, profile := range profiles {
 		pkgName := getPackageName(profile.FileName)
 		pkgPkg := pkgMap[pkgName]
-		if err := cov.parseProfile(profile, pkgPkg, ignore); err != nil {
+		if err := cov.parseProfile(profile, pkgName, pkgPkg, ignore, byFiles); err != nil {
 			return err
 		}
 	}
+	cov.computeRates()
+	return nil
+}
+
+// computeRates fills the totals and rates of the coverage report from its packages
+func (cov *Coverage) computeRates() {
 	cov.LinesValid = cov.NumLines()
 	cov.LinesCovered = cov.NumLinesWithHits()
 	cov.LineRate = cov.HitRate()
-	return nil
+	cov.BranchesValid = cov.NumBranches()
+	cov.BranchesCovered = cov.NumBranchesCovered()
+	cov.BranchRate = cov.BranchHitRate()
+	cov.Complexity = averageComplexity(cov.AllMethods())
 }
 
-func (cov *Coverage) parseProfile(profile *Profile, pkgPkg *packages.Package, ignore *Ignore) error {
+func (cov *Coverage) parseProfile(profile *Profile, pkgName string, pkgPkg *packages.Package, ignore *Ignore, byFiles bool) error {
 	if pkgPkg == nil || pkgPkg.Module == nil {
-		return fmt.Errorf("package required when using go modules")
+		return fmt.Errorf("package required when using go modules: %s was not found in a module", pkgName)
 	}
-	fileName := profile.FileName[len(pkgPkg.Module.Path)+1:]
 	absFilePath := findAbsFilePath(pkgPkg, profile.FileName)
+	if absFilePath == "" {
+		return fmt.Errorf("source file of %s was not found in %s", profile.FileName, pkgName)
+	}
+	fileName := moduleFileName(pkgPkg.Module, absFilePath, profile.FileName)
 	fset := token.NewFileSet()
-	parsed, err := parser.ParseFile(fset, absFilePath, nil, 0)
+	parsed, err := parser.ParseFile(fset, absFilePath, nil, parser.ParseComments)
 	if err != nil {
 		return err
 	}
@@ -260,56 +388,82 @@ func (cov *Coverage) parseProfile(profile *Profile, pkgPkg *packages.Package, ig
 		return nil
 	}
 
-	pkgPath, _ := filepath.Split(fileName)
-	pkgPath = strings.TrimRight(strings.TrimRight(pkgPath, "/"), "\\")
-	pkgPath = filepath.Join(pkgPkg.Module.Path, pkgPath)
-	// TODO(boumenot): package paths are not file paths, there is a consistent separator
-	pkgPath = strings.Replace(pkgPath, "\\", "/", -1)
+	cov.Sources = AppendIfUnique(cov.Sources, pkgPkg.Module.Dir)
 
 	var pkg *Package
 	for _, p := range cov.Packages {
-		if p.Name == pkgPath {
+		if p.Name == pkgName {
 			pkg = p
 		}
 	}
 	if pkg == nil {
-		pkg = &Package{Name: pkgPkg.ID, Classes: []*Class{}}
+		pkg = &Package{Name: pkgName, Classes: []*Class{}}
 		cov.Packages = append(cov.Packages, pkg)
 	}
+	pragmas := coveragePragmas(fset, parsed)
 	visitor := &fileVisitor{
 		fset:     fset,
 		fileName: fileName,
 		fileData: data,
+		source:   pkgPkg.Module.Dir,
 		classes:  make(map[string]*Class),
 		pkg:      pkg,
 		profile:  profile,
+		byFiles:  byFiles,
+		pragmas:  pragmas,
+		ignored:  ignoredRanges(pragmas),
 	}
 	ast.Walk(visitor, parsed)
 	pkg.LineRate = pkg.HitRate()
+	pkg.BranchRate = pkg.BranchHitRate()
+	pkg.Complexity = averageComplexity(pkg.AllMethods())
 	return nil
 }
 
+// moduleFileName returns the slash separated path of a source file relative to the directory of its module,
+// falling back to the import path of the file without the module path
+func moduleFileName(module *packages.Module, absFilePath string, profileName string) string {
+	if module.Dir != "" {
+		if fileName, err := filepath.Rel(module.Dir, absFilePath); err == nil && !strings.HasPrefix(fileName, "..") {
+			return filepath.ToSlash(fileName)
+		}
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(profileName, module.Path), "/")
+}
+
 type fileVisitor struct {
 	fset     *token.FileSet
 	fileName string
 	fileData []byte
+	source   string
 	pkg      *Package
 	classes  map[string]*Class
 	profile  *Profile
 	byFiles  bool
+	pragmas  map[int]string
+	ignored  []lineRange
 }
 
 func (v *fileVisitor) Visit(node ast.Node) ast.Visitor {
 	switch n := node.(type) {
 	case *ast.FuncDecl:
+		if v.isIgnoredFunc(n) {
+			return nil
+		}
 		class := v.class(n)
 		method := v.method(n)
+		v.branches(n, method)
 		method.LineRate = method.Lines.HitRate()
+		method.BranchRate = method.Lines.BranchHitRate()
+		method.Complexity = float32(cyclomaticComplexity(n))
 		class.Methods = append(class.Methods, method)
 		for _, line := range method.Lines {
 			class.Lines = append(class.Lines, line)
 		}
 		class.LineRate = class.Lines.HitRate()
+		class.BranchRate = class.Lines.BranchHitRate()
+		class.Complexity = averageComplexity(class.Methods)
 	}
 	return v
 }
@@ -320,6 +474,8 @@ func (v *fileVisitor) method(n *ast.FuncDecl) *Method {
 
 	start := v.fset.Position(n.Pos())
 	end := v.fset.Position(n.End())
+	method.StartLine = start.Line
+	method.EndLine = end.Line
 	startLine := start.Line
 	startCol := start.Column
 	endLine := end.Line
@@ -335,6 +491,9 @@ func (v *fileVisitor) method(n *ast.FuncDecl) *Method {
 			continue
 		}
 		for i := b.StartLine; i <= b.EndLine; i++ {
+			if v.isIgnoredLine(i) {
+				continue
+			}
 			method.Lines.AddOrUpdateLine(i, int64(b.Count))
 		}
 	}
@@ -359,7 +518,7 @@ func (v *fileVisitor) class(n *ast.FuncDecl) *Class {
 	}
 	class := v.classes[className]
 	if class == nil {
-		class = &Class{Name: className, Filename: v.fileName, Methods: []*Method{}, Lines: []*Line{}}
+		class = &Class{Name: className, Filename: v.fileName, Source: v.source, Methods: []*Method{}, Lines: []*Line{}}
 		v.classes[className] = class
 		v.pkg.Classes = append(v.pkg.Classes, class)
 	}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// generatedProfile covers testdata/generated, a module with a generated file
+const generatedProfile = `mode: set
+example.com/generated/shapes/shapes.go:9.33,11.2 1 1
+example.com/generated/shapes/shapes.go:19.33,21.2 1 0
+example.com/generated/shapes/shapes.go:24.38,26.30 2 1
+example.com/generated/shapes/shapes.go:26.30,28.3 1 1
+example.com/generated/shapes/shapes.go:29.2,29.14 1 1
+example.com/generated/shapes/shapes_string.go:8.31,9.12 1 1
+example.com/generated/shapes/shapes_string.go:9.12,11.3 1 1
+example.com/generated/shapes/shapes_string.go:12.2,12.17 1 0
+`
+
+func parseGeneratedTestdata(t *testing.T, ignore *Ignore, byFiles bool) *Coverage {
+	profiles, err := ParseProfiles(strings.NewReader(generatedProfile), ignore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := FindModules("testdata/generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, err := ResolvePackages(profiles, modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgMap := make(map[string]*packages.Package)
+	for _, pkg := range pkgs {
+		pkgMap[pkg.ID] = pkg
+	}
+
+	cov := &Coverage{}
+	if err := cov.ParseProfiles(profiles, pkgMap, ignore, byFiles); err != nil {
+		t.Fatal(err)
+	}
+
+	return cov
+}
+
+func classNames(cov *Coverage) []string {
+	names := make([]string, 0)
+	for _, pkg := range cov.Packages {
+		for _, class := range pkg.Classes {
+			names = append(names, class.Name+" "+class.Filename)
+		}
+	}
+	return names
+}
+
+func TestParseProfilesIgnoreGeneratedFiles(t *testing.T) {
+	cov := parseGeneratedTestdata(t, &Ignore{}, false)
+	if cov.LinesValid != 17 {
+		t.Errorf("expected the generated file to be reported, got %d lines", cov.LinesValid)
+	}
+
+	cov = parseGeneratedTestdata(t, &Ignore{GeneratedFiles: true}, false)
+	for _, name := range classNames(cov) {
+		if strings.HasSuffix(name, "shapes_string.go") {
+			t.Errorf("expected the generated file to be ignored, got class %s", name)
+		}
+	}
+	if cov.LinesValid != 12 {
+		t.Errorf("expected 12 lines without the generated file, got %d", cov.LinesValid)
+	}
+}
+
+func TestParseProfilesByFiles(t *testing.T) {
+	expected := "Square shapes/shapes.go,Circle shapes/shapes.go,- shapes/shapes.go,Kind shapes/shapes_string.go"
+	if names := strings.Join(classNames(parseGeneratedTestdata(t, &Ignore{}, false)), ","); names != expected {
+		t.Errorf("expected classes per receiver %s, got %s", expected, names)
+	}
+
+	expected = "shapes.shapes.go shapes/shapes.go,shapes.shapes_string.go shapes/shapes_string.go"
+	if names := strings.Join(classNames(parseGeneratedTestdata(t, &Ignore{}, true)), ","); names != expected {
+		t.Errorf("expected classes per file %s, got %s", expected, names)
+	}
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// cyclomaticComplexity returns the cyclomatic complexity of a function: one plus the number of
+// if, for and range statements, non default case and comm clauses, and && and || operators
+func cyclomaticComplexity(fn ast.Node) int {
+	complexity := 1
+	ast.Inspect(fn, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if n.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+// averageComplexity returns the average complexity of the methods, as Cobertura does for classes and packages
+func averageComplexity(methods []*Method) float32 {
+	if len(methods) == 0 {
+		return 0
+	}
+
+	var complexity float32
+	for _, method := range methods {
+		complexity += method.Complexity
+	}
+	return complexity / float32(len(methods))
+}
+
+// AllMethods returns the methods of every class of the package
+func (pkg Package) AllMethods() []*Method {
+	methods := make([]*Method, 0)
+	for _, class := range pkg.Classes {
+		methods = append(methods, class.Methods...)
+	}
+	return methods
+}
+
+// AllMethods returns the methods of every package
+func (cov Coverage) AllMethods() []*Method {
+	methods := make([]*Method, 0)
+	for _, pkg := range cov.Packages {
+		methods = append(methods, pkg.AllMethods()...)
+	}
+	return methods
+}
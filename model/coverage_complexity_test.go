@@ -0,0 +1,20 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestCyclomaticComplexity(t *testing.T) {
+	pkg := visitTestdata(t, "testdata/branch/branch.go", branchProfile)
+
+	expected := map[string]float32{"Sign": 3, "Clamp": 2, "Kind": 3, "Sum": 2}
+	for _, method := range pkg.AllMethods() {
+		if method.Complexity != expected[method.Name] {
+			t.Errorf("%s: expected complexity %v, got %v", method.Name, expected[method.Name], method.Complexity)
+		}
+	}
+
+	if complexity := pkg.Classes[0].Complexity; complexity != 2.5 {
+		t.Errorf("expected an average class complexity of 2.5, got %v", complexity)
+	}
+}
@@ -0,0 +1,383 @@
+package model
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// Binary coverage data written to GOCOVERDIR by programs built with -cover (Go 1.20+).
+// A meta-data file "covmeta.<hash>" describes the coverable units of every package,
+// each run of the program writes a counter file "covcounters.<hash>.<pid>.<time>".
+//
+// References:
+// https://go.dev/doc/build-cover
+// https://go.dev/src/internal/coverage/defs.go
+const (
+	covMetaFileHeaderSize    = 56
+	covMetaPackageHeaderSize = 44
+	covCounterHeaderSize     = 32
+	covCounterFooterSize     = 16
+
+	covCounterFlavorRaw     = 1
+	covCounterFlavorULEB128 = 2
+)
+
+var (
+	covMetaMagic    = []byte{0x00, 'c', 'v', 'm'}
+	covCounterMagic = []byte{0x00, 'c', 'w', 'm'}
+
+	covMetaFileRe    = regexp.MustCompile(`^covmeta\.([0-9a-f]+)$`)
+	covCounterFileRe = regexp.MustCompile(`^covcounters\.([0-9a-f]+)\.\d+\.\d+$`)
+
+	covCounterModes = map[uint8]string{1: "set", 2: "count", 3: "atomic"}
+)
+
+// covFunc represents a function of a meta-data file and its coverable units
+type covFunc struct {
+	srcFile string
+	units   []covUnit
+}
+
+// covUnit represents a coverable unit, the equivalent of a profile block
+type covUnit struct {
+	startLine, startCol int
+	endLine, endCol     int
+	numStmt             int
+}
+
+// covFuncKey identifies a function by its package and function index in a meta-data file
+type covFuncKey struct {
+	pkgIdx  uint32
+	funcIdx uint32
+}
+
+// WriteCovDataProfile decodes the binary coverage data of a GOCOVERDIR directory and writes it
+// in the text profile format, as "go tool covdata textfmt" does, so it can be parsed by ParseProfiles.
+// The counters of the runs of a program are or-ed in set mode and summed otherwise.
+func WriteCovDataProfile(dir string, out io.Writer) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	counterFiles := make(map[string][]string)
+	metaFiles := make([]string, 0)
+	for _, entry := range entries {
+		if m := covMetaFileRe.FindStringSubmatch(entry.Name()); m != nil {
+			metaFiles = append(metaFiles, entry.Name())
+		} else if m := covCounterFileRe.FindStringSubmatch(entry.Name()); m != nil {
+			counterFiles[m[1]] = append(counterFiles[m[1]], entry.Name())
+		}
+	}
+	if len(metaFiles) == 0 {
+		return fmt.Errorf("no coverage meta-data file found in %s", dir)
+	}
+	sort.Strings(metaFiles)
+
+	mode := ""
+	profiles := make(map[string][]ProfileBlock)
+	for _, metaFile := range metaFiles {
+		hash := covMetaFileRe.FindStringSubmatch(metaFile)[1]
+
+		metaMode, funcs, err := readCovMetaFile(filepath.Join(dir, metaFile))
+		if err != nil {
+			return fmt.Errorf("%s: %v", metaFile, err)
+		}
+		if mode != "" && metaMode != mode {
+			return fmt.Errorf("inconsistent mode: %s uses %q, expected %q", metaFile, metaMode, mode)
+		}
+		mode = metaMode
+
+		counters := make(map[covFuncKey][]uint32)
+		sort.Strings(counterFiles[hash])
+		for _, counterFile := range counterFiles[hash] {
+			if err := readCovCounterFile(filepath.Join(dir, counterFile), mode, counters); err != nil {
+				return fmt.Errorf("%s: %v", counterFile, err)
+			}
+		}
+
+		for key, fn := range funcs {
+			for idx, unit := range fn.units {
+				count := 0
+				if idx < len(counters[key]) {
+					count = int(counters[key][idx])
+				}
+				profiles[fn.srcFile] = append(profiles[fn.srcFile], ProfileBlock{
+					StartLine: unit.startLine,
+					StartCol:  unit.startCol,
+					EndLine:   unit.endLine,
+					EndCol:    unit.endCol,
+					NumStmt:   unit.numStmt,
+					Count:     count,
+				})
+			}
+		}
+	}
+
+	fileNames := make([]string, 0, len(profiles))
+	for fileName := range profiles {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	if _, err := fmt.Fprintf(out, "mode: %s\n", mode); err != nil {
+		return err
+	}
+	for _, fileName := range fileNames {
+		blocks := profiles[fileName]
+		sort.Sort(blocksByStart(blocks))
+		for _, b := range blocks {
+			if _, err := fmt.Fprintf(out, "%s:%d.%d,%d.%d %d %d\n", fileName, b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt, b.Count); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readCovMetaFile returns the counter mode and the functions of every package of a meta-data file
+func readCovMetaFile(fileName string) (string, map[covFuncKey]*covFunc, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	r := &covDataReader{data: data}
+	if !bytes.Equal(r.bytes(4), covMetaMagic) {
+		return "", nil, errors.New("not a coverage meta-data file")
+	}
+	// magic, version, total length, entries, hash, string table offset and length, mode, granularity
+	r.seek(16)
+	entries := r.uint64()
+	r.seek(48)
+	mode, ok := covCounterModes[r.uint8()]
+	if r.err == nil && !ok {
+		return "", nil, errors.New("unsupported counter mode")
+	}
+
+	r.seek(covMetaFileHeaderSize)
+	offsets := make([]uint64, entries)
+	for idx := range offsets {
+		offsets[idx] = r.uint64()
+	}
+	lengths := make([]uint64, entries)
+	for idx := range lengths {
+		lengths[idx] = r.uint64()
+	}
+	if r.err != nil {
+		return "", nil, r.err
+	}
+
+	funcs := make(map[covFuncKey]*covFunc)
+	for pkgIdx := range offsets {
+		if offsets[pkgIdx]+lengths[pkgIdx] > uint64(len(data)) {
+			return "", nil, fmt.Errorf("package %d is out of bounds", pkgIdx)
+		}
+		payload := data[offsets[pkgIdx] : offsets[pkgIdx]+lengths[pkgIdx]]
+		if err := readCovMetaPackage(payload, uint32(pkgIdx), funcs); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return mode, funcs, nil
+}
+
+// readCovMetaPackage adds the functions of a package payload of a meta-data file
+func readCovMetaPackage(payload []byte, pkgIdx uint32, funcs map[covFuncKey]*covFunc) error {
+	r := &covDataReader{data: payload}
+	r.seek(covMetaPackageHeaderSize - 4)
+	numFuncs := r.uint32()
+
+	funcOffsets := make([]uint32, numFuncs)
+	for idx := range funcOffsets {
+		funcOffsets[idx] = r.uint32()
+	}
+	strs := r.stringTable()
+
+	for funcIdx, funcOffset := range funcOffsets {
+		r.seek(int(funcOffset))
+		numUnits := r.uleb128()
+		r.uleb128() // function name
+		fileIdx := r.uleb128()
+		if r.err != nil {
+			return r.err
+		}
+		if fileIdx >= uint64(len(strs)) {
+			return fmt.Errorf("bad file index %d", fileIdx)
+		}
+
+		fn := &covFunc{srcFile: strs[fileIdx]}
+		for idx := uint64(0); idx < numUnits && r.err == nil; idx++ {
+			fn.units = append(fn.units, covUnit{
+				startLine: int(r.uleb128()),
+				startCol:  int(r.uleb128()),
+				endLine:   int(r.uleb128()),
+				endCol:    int(r.uleb128()),
+				numStmt:   int(r.uleb128()),
+			})
+		}
+		funcs[covFuncKey{pkgIdx: pkgIdx, funcIdx: uint32(funcIdx)}] = fn
+	}
+
+	return r.err
+}
+
+// readCovCounterFile merges the counters of every segment of a counter file into counters
+func readCovCounterFile(fileName string, mode string, counters map[covFuncKey][]uint32) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	r := &covDataReader{data: data}
+	if !bytes.Equal(r.bytes(4), covCounterMagic) {
+		return errors.New("not a coverage counter file")
+	}
+	// magic, version, meta-data hash, flavor, big endian
+	r.seek(24)
+	flavor, bigEndian := r.uint8(), r.uint8() != 0
+	if r.err == nil && flavor != covCounterFlavorRaw && flavor != covCounterFlavorULEB128 {
+		return fmt.Errorf("unknown counter flavor %d", flavor)
+	}
+
+	footer := &covDataReader{data: data}
+	footer.seek(len(data) - covCounterFooterSize)
+	if !bytes.Equal(footer.bytes(4), covCounterMagic) {
+		return errors.New("bad counter file footer")
+	}
+	footer.uint32()
+	numSegments := footer.uint32()
+
+	counter := func() uint32 {
+		switch {
+		case flavor == covCounterFlavorULEB128:
+			return uint32(r.uleb128())
+		case bigEndian:
+			return binary.BigEndian.Uint32(r.bytes(4))
+		default:
+			return r.uint32()
+		}
+	}
+
+	r.seek(covCounterHeaderSize)
+	for segment := uint32(0); segment < numSegments && r.err == nil; segment++ {
+		numFuncs := r.uint64()
+		strTabLength, argsLength := r.uint32(), r.uint32()
+		r.bytes(int(strTabLength) + int(argsLength))
+		if r.off%4 != 0 {
+			r.seek(r.off + 4 - r.off%4)
+		}
+
+		for idx := uint64(0); idx < numFuncs && r.err == nil; idx++ {
+			numCounters := counter()
+			key := covFuncKey{pkgIdx: counter(), funcIdx: counter()}
+
+			values := counters[key]
+			for len(values) < int(numCounters) && r.err == nil {
+				values = append(values, 0)
+			}
+			for ctrIdx := uint32(0); ctrIdx < numCounters && r.err == nil; ctrIdx++ {
+				values[ctrIdx] = mergeCovCounter(mode, values[ctrIdx], counter())
+			}
+			counters[key] = values
+		}
+
+		// Every segment is followed by a footer
+		r.bytes(covCounterFooterSize)
+	}
+
+	return r.err
+}
+
+// mergeCovCounter merges the counters of two runs, saturating in count and atomic modes
+func mergeCovCounter(mode string, a uint32, b uint32) uint32 {
+	if mode == "set" {
+		if a != 0 || b != 0 {
+			return 1
+		}
+		return 0
+	}
+
+	if uint64(a)+uint64(b) > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return a + b
+}
+
+// covDataReader reads little endian values from a coverage data file, the first error sticks
+type covDataReader struct {
+	data []byte
+	off  int
+	err  error
+}
+
+func (r *covDataReader) seek(off int) {
+	if r.err == nil && (off < 0 || off > len(r.data)) {
+		r.err = io.ErrUnexpectedEOF
+	}
+	if r.err == nil {
+		r.off = off
+	}
+}
+
+func (r *covDataReader) bytes(n int) []byte {
+	if r.err == nil && (n < 0 || r.off+n > len(r.data)) {
+		r.err = io.ErrUnexpectedEOF
+	}
+	if r.err != nil {
+		// Fixed size values read as zero once an error occurred
+		if n > 8 {
+			return nil
+		}
+		return make([]byte, n)
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *covDataReader) uint8() uint8 {
+	return r.bytes(1)[0]
+}
+
+func (r *covDataReader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.bytes(4))
+}
+
+func (r *covDataReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.bytes(8))
+}
+
+func (r *covDataReader) uleb128() uint64 {
+	var value uint64
+	var shift uint
+	for r.err == nil {
+		b := r.uint8()
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+	}
+	return value
+}
+
+// stringTable reads a ULEB128 count followed by ULEB128 length prefixed strings
+func (r *covDataReader) stringTable() []string {
+	count := r.uleb128()
+	strs := make([]string, 0)
+	for idx := uint64(0); idx < count && r.err == nil; idx++ {
+		length := r.uleb128()
+		strs = append(strs, string(r.bytes(int(length))))
+	}
+	return strs
+}
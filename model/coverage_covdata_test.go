@@ -0,0 +1,33 @@
+package model
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// testdata/covdata was written by a program built with -covermode=count and run twice,
+// testdata/covdata.txt is the output of go tool covdata textfmt for it
+func TestWriteCovDataProfile(t *testing.T) {
+	expected, err := os.ReadFile("testdata/covdata.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteCovDataProfile("testdata/covdata", &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.String() != string(expected) {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	profiles, err := ParseProfiles(&out, &Ignore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || profiles[0].Mode != "count" || len(profiles[0].Blocks) != 7 {
+		t.Errorf("unexpected profiles %+v", profiles)
+	}
+}
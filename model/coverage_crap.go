@@ -0,0 +1,89 @@
+package model
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	ReportEngineCrap = "crap"
+	CrapCheckName    = "crap-score"
+)
+
+// CrapScore returns the Change Risk Anti-Patterns score of the method,
+// complexity² × (1 − coverage)³ + complexity, high for complex and poorly tested functions.
+func (method Method) CrapScore() float64 {
+	complexity := float64(method.Complexity)
+	uncovered := 1 - float64(method.LineRate)
+	if method.NumLines() == 0 {
+		uncovered = 1
+	}
+
+	return complexity*complexity*math.Pow(uncovered, 3) + complexity
+}
+
+// CrapReports returns a code quality issue for every method whose CRAP score exceeds the threshold.
+// Methods without a complexity, e.g. converted from LCOV, or without instrumented lines are skipped.
+func (cov Coverage) CrapReports(threshold float64, reportType string) []*Report {
+	reports := make([]*Report, 0)
+
+	for _, pkg := range cov.Packages {
+		for _, class := range pkg.Classes {
+			for _, method := range class.Methods {
+				if method.Complexity == 0 || method.NumLines() == 0 {
+					continue
+				}
+
+				score := method.CrapScore()
+				if score <= threshold {
+					continue
+				}
+
+				reports = append(reports, newCrapReport(class, method, score, threshold, reportType))
+			}
+		}
+	}
+
+	return reports
+}
+
+func newCrapReport(class *Class, method *Method, score float64, threshold float64, reportType string) *Report {
+	name := method.Name
+	if class.Name != "-" && class.Name != "" && class.Filename != class.Name {
+		name = class.Name + "." + method.Name
+	}
+
+	newReport := &Report{
+		EngineName: ReportEngineCrap,
+		Type:       reportType,
+		CheckName:  CrapCheckName,
+		Categories: []string{Complexity},
+		Location: ReportLocation{
+			Path: class.Path(),
+			Positions: ReportLocationPositions{
+				Begin: ReportLocationPositionsData{Line: method.StartLine},
+				End:   ReportLocationPositionsData{Line: method.EndLine},
+			},
+		},
+		Description: fmt.Sprintf("Function %s has a CRAP score of %.1f (complexity %.0f, coverage %.1f%%)", name, score, method.Complexity, 100*method.LineRate),
+		Content: ReportContent{
+			Body: fmt.Sprintf("The CRAP score combines the cyclomatic complexity and the test coverage of a function, "+
+				"it exceeds the threshold of %.1f. Add tests or reduce the complexity of the function.", threshold),
+		},
+	}
+
+	newReport.SetDefaults()
+
+	switch {
+	case score >= 4*threshold:
+		newReport.Severity = SeverityCritical
+	case score >= 2*threshold:
+		newReport.Severity = SeverityMajor
+	default:
+		newReport.Severity = SeverityMinor
+	}
+
+	newReport.ComputeFingerprint()
+
+	return newReport
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrapScore(t *testing.T) {
+	covered := &Line{Number: 1, Hits: 1}
+	uncovered := &Line{Number: 2, Hits: 0}
+
+	tests := []struct {
+		method Method
+		score  float64
+	}{
+		{Method{Complexity: 5, LineRate: 1, Lines: Lines{covered}}, 5},
+		{Method{Complexity: 5, LineRate: 0, Lines: Lines{uncovered}}, 30},
+		{Method{Complexity: 4, LineRate: 0.5, Lines: Lines{covered, uncovered}}, 6},
+		{Method{Complexity: 2}, 6},
+	}
+
+	for _, test := range tests {
+		if score := test.method.CrapScore(); score != test.score {
+			t.Errorf("complexity %v coverage %v: expected a CRAP score of %v, got %v", test.method.Complexity, test.method.LineRate, test.score, score)
+		}
+	}
+}
+
+func TestCrapReports(t *testing.T) {
+	pkg := visitTestdata(t, "testdata/branch/branch.go", branchProfile)
+	cov := Coverage{Packages: []*Package{pkg}}
+
+	reports := cov.CrapReports(4, ReportTypeIssue)
+	if len(reports) == 0 {
+		t.Fatal("expected CRAP issues")
+	}
+
+	for _, report := range reports {
+		if report.EngineName != ReportEngineCrap || report.CheckName != CrapCheckName {
+			t.Errorf("unexpected engine %s and check %s", report.EngineName, report.CheckName)
+		}
+		if len(report.Categories) != 1 || report.Categories[0] != Complexity {
+			t.Errorf("expected the Complexity category, got %v", report.Categories)
+		}
+		if report.Location.Path != "testdata/branch/branch.go" || report.Location.Positions.Begin.Line == 0 {
+			t.Errorf("unexpected location %+v", report.Location)
+		}
+		if report.Fingerprint == "" {
+			t.Errorf("expected a fingerprint for %s", report.Description)
+		}
+	}
+
+	// A method without instrumented lines has no coverage rate
+	noLines := &Class{Name: "-", Filename: "empty.go", Methods: []*Method{{Name: "Empty", Complexity: 10, LineRate: float32(math.NaN())}}}
+	noLinesCov := Coverage{Packages: []*Package{{Classes: []*Class{noLines}}}}
+	if reports := noLinesCov.CrapReports(4, ReportTypeIssue); len(reports) != 0 {
+		t.Errorf("expected methods without lines to be skipped, got %q", reports[0].Description)
+	}
+
+	if reports := cov.CrapReports(1000, ReportTypeIssue); len(reports) != 0 {
+		t.Errorf("expected no issues above a high threshold, got %d", len(reports))
+	}
+}
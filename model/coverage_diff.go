@@ -0,0 +1,98 @@
+package model
+
+import (
+	"sort"
+)
+
+// DiffCoverage holds the coverage of the lines changed by a diff
+type DiffCoverage struct {
+	Files []*FileDiffCoverage
+}
+
+// FileDiffCoverage holds the covered and uncovered changed lines of a file.
+// Changed lines without statements, e.g. comments, are not part of either list.
+type FileDiffCoverage struct {
+	Filename  string
+	Covered   []int
+	Uncovered []int
+}
+
+// DiffCoverage computes the coverage of the lines changed by the diff
+func (cov *Coverage) DiffCoverage(diff *Diff) *DiffCoverage {
+	// A file may hold several classes, one per receiver
+	fileLines := make(map[string]map[int]int64)
+	for _, pkg := range cov.Packages {
+		for _, class := range pkg.Classes {
+			// File names are relative to the module, the path tells apart files of different modules
+			path := class.Path()
+			if fileLines[path] == nil {
+				fileLines[path] = make(map[int]int64)
+			}
+			for _, line := range class.Lines {
+				fileLines[path][line.Number] += line.Hits
+			}
+		}
+	}
+
+	fileNames := make([]string, 0, len(fileLines))
+	for fileName := range fileLines {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	diffCoverage := &DiffCoverage{Files: make([]*FileDiffCoverage, 0)}
+	for _, fileName := range fileNames {
+		fileDiff := diff.File(fileName)
+		if fileDiff == nil {
+			continue
+		}
+
+		fileCoverage := &FileDiffCoverage{Filename: fileName}
+		for lineNumber, hits := range fileLines[fileName] {
+			if !fileDiff.Contains(lineNumber) {
+				continue
+			}
+
+			if hits > 0 {
+				fileCoverage.Covered = append(fileCoverage.Covered, lineNumber)
+			} else {
+				fileCoverage.Uncovered = append(fileCoverage.Uncovered, lineNumber)
+			}
+		}
+
+		if len(fileCoverage.Covered)+len(fileCoverage.Uncovered) == 0 {
+			continue
+		}
+
+		sort.Ints(fileCoverage.Covered)
+		sort.Ints(fileCoverage.Uncovered)
+		diffCoverage.Files = append(diffCoverage.Files, fileCoverage)
+	}
+
+	return diffCoverage
+}
+
+// HitRate returns a float32 from 0.0 to 1.0 representing what fraction of changed lines
+// have hits, a diff without changed lines is fully covered
+func (d *DiffCoverage) HitRate() float32 {
+	if d.NumLines() == 0 {
+		return 1
+	}
+	return float32(d.NumLinesWithHits()) / float32(d.NumLines())
+}
+
+// NumLines returns the number of changed lines
+func (d *DiffCoverage) NumLines() (numLines int64) {
+	for _, file := range d.Files {
+		numLines += int64(len(file.Covered) + len(file.Uncovered))
+	}
+	return numLines
+}
+
+// NumLinesWithHits returns the number of changed lines with a hit count > 0
+func (d *DiffCoverage) NumLinesWithHits() (numLinesWithHits int64) {
+	for _, file := range d.Files {
+		numLinesWithHits += int64(len(file.Covered))
+	}
+	return numLinesWithHits
+}
@@ -15,32 +15,36 @@ type Ignore struct {
 	cache          map[string]bool
 }
 
-func (i *Ignore) Match(fileName string, data []byte) (ret bool) {
+// Match reports whether the file is ignored by its directory, its name or, when data is provided,
+// its content: a coverage:ignore-file pragma or, when GeneratedFiles is set, a generated code header.
+// Only the matches by name are cached, as files of different modules may share a name.
+func (i *Ignore) Match(fileName string, data []byte) bool {
 	if i.cache == nil {
 		i.cache = map[string]bool{}
-	} else if match, exists := i.cache[fileName]; exists {
-		return match
 	}
 
-	dir := filepath.Dir(fileName)
+	match, exists := i.cache[fileName]
+	if !exists {
+		match = i.dirMatch(filepath.Dir(fileName)) || (i.Files != nil && i.Files.MatchString(fileName))
+		i.cache[fileName] = match
+	}
 
-	if i.dirMatch(dir) ||
-		(i.Files != nil && i.Files.MatchString(fileName)) {
-		ret = true
-	} else if i.GeneratedFiles {
-		if data == nil {
-			return false // no cache if no content provided
-		}
+	if match || data == nil {
+		return match
+	}
 
-		if len(data) > 256 {
-			data = data[:256]
-		}
-		ret = genCodeRe.Match(data)
+	if ignoreFileRe.Match(data) {
+		return true
 	}
 
-	i.cache[fileName] = ret
+	if !i.GeneratedFiles {
+		return false
+	}
 
-	return ret
+	if len(data) > 256 {
+		data = data[:256]
+	}
+	return genCodeRe.Match(data)
 }
 
 func (i *Ignore) dirMatch(dir string) bool {
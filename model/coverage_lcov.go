@@ -0,0 +1,296 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CoverageFormatGo   = "go"
+	CoverageFormatLCOV = "lcov"
+)
+
+// LCOVFile represents the records of a source file in a LCOV tracefile.
+// SF:<path> FN:<line>,<name> FNDA:<hits>,<name> DA:<line>,<hits> BRDA:<line>,<block>,<branch>,<taken> end_of_record
+//
+// References:
+// https://manpages.debian.org/unstable/lcov/geninfo.1.en.html#TRACEFILE_FORMAT
+type LCOVFile struct {
+	SourceFile string
+	Functions  []*LCOVFunction
+	Lines      []*LCOVLine
+	Branches   []*LCOVBranch
+}
+
+// LCOVFunction represents a FN record and the hits of its FNDA record
+type LCOVFunction struct {
+	Name      string
+	StartLine int
+	EndLine   int
+	Hits      int64
+}
+
+// LCOVLine represents a DA record
+type LCOVLine struct {
+	Number int
+	Hits   int64
+}
+
+// LCOVBranch represents a BRDA record, a branch never evaluated ("-") is not taken.
+// ID is made of the line, block and branch numbers.
+type LCOVBranch struct {
+	ID    string
+	Line  int
+	Taken int64
+}
+
+// DetectCoverageFormat sniffs the first line of a coverage input and returns its format
+func DetectCoverageFormat(data []byte) string {
+	firstLine := bytes.TrimSpace(data)
+	if idx := bytes.IndexByte(firstLine, '\n'); idx >= 0 {
+		firstLine = bytes.TrimSpace(firstLine[:idx])
+	}
+
+	for _, prefix := range []string{"TN:", "SF:"} {
+		if bytes.HasPrefix(firstLine, []byte(prefix)) {
+			return CoverageFormatLCOV
+		}
+	}
+
+	return CoverageFormatGo
+}
+
+// ParseLCOV parses a LCOV tracefile, as produced by Jest/Istanbul, c8 or geninfo
+func ParseLCOV(in io.Reader) ([]*LCOVFile, error) {
+	files := make([]*LCOVFile, 0)
+	fileMap := make(map[string]*LCOVFile)
+
+	var file *LCOVFile
+	var functions map[string]*LCOVFunction
+	var lines map[int]*LCOVLine
+	var branches map[string]*LCOVBranch
+
+	s := bufio.NewScanner(in)
+	s.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "end_of_record" {
+			file = nil
+			continue
+		}
+
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		record, value := line[:idx], line[idx+1:]
+
+		if record == "SF" {
+			// Tracefiles merged by hand may list a file several times
+			file = fileMap[value]
+			if file == nil {
+				file = &LCOVFile{SourceFile: value}
+				fileMap[value] = file
+				files = append(files, file)
+			}
+
+			functions = make(map[string]*LCOVFunction)
+			for _, function := range file.Functions {
+				functions[function.Name] = function
+			}
+
+			lines = make(map[int]*LCOVLine)
+			for _, lcovLine := range file.Lines {
+				lines[lcovLine.Number] = lcovLine
+			}
+
+			branches = make(map[string]*LCOVBranch)
+			for _, branch := range file.Branches {
+				branches[branch.ID] = branch
+			}
+			continue
+		}
+
+		if file == nil {
+			continue
+		}
+
+		fields := strings.Split(value, ",")
+		switch record {
+		case "FN":
+			// FN:<line>,<name> or FN:<line>,<end line>,<name>, names may contain commas, e.g. C++ templates
+			fields := strings.SplitN(value, ",", 3)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("bad FN record: %v", line)
+			}
+			function := &LCOVFunction{Name: strings.Join(fields[1:], ",")}
+			function.StartLine, _ = strconv.Atoi(fields[0])
+			if len(fields) > 2 {
+				if endLine, err := strconv.Atoi(fields[1]); err == nil {
+					function.EndLine = endLine
+					function.Name = fields[2]
+				}
+			}
+			if functions[function.Name] == nil {
+				functions[function.Name] = function
+				file.Functions = append(file.Functions, function)
+			}
+		case "FNDA":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("bad FNDA record: %v", line)
+			}
+			hits, _ := strconv.ParseInt(fields[0], 10, 64)
+			if function := functions[strings.Join(fields[1:], ",")]; function != nil {
+				function.Hits += hits
+			}
+		case "DA":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("bad DA record: %v", line)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("bad DA record: %v", line)
+			}
+			hits, _ := strconv.ParseInt(fields[1], 10, 64)
+			// The hits of a line listed in several tracefiles are summed
+			if lcovLine := lines[number]; lcovLine != nil {
+				lcovLine.Hits += hits
+				continue
+			}
+			lines[number] = &LCOVLine{Number: number, Hits: hits}
+			file.Lines = append(file.Lines, lines[number])
+		case "BRDA":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("bad BRDA record: %v", line)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("bad BRDA record: %v", line)
+			}
+			taken, _ := strconv.ParseInt(fields[3], 10, 64)
+			id := strings.Join(fields[:3], ",")
+			if branch := branches[id]; branch != nil {
+				branch.Taken += taken
+				continue
+			}
+			branches[id] = &LCOVBranch{ID: id, Line: number, Taken: taken}
+			file.Branches = append(file.Branches, branches[id])
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// ParseLCOV builds the coverage report from LCOV files, with a package per directory and a class per file.
+// Paths are made relative to the source root, which is the single source of the report.
+func (cov *Coverage) ParseLCOV(files []*LCOVFile, sourceRoot string, ignore *Ignore) error {
+	cov.Packages = []*Package{}
+	cov.Sources = AppendIfUnique(cov.Sources, sourceRoot)
+
+	pkgMap := make(map[string]*Package)
+	for _, file := range files {
+		fileName := file.SourceFile
+		if filepath.IsAbs(fileName) {
+			if relFileName, err := filepath.Rel(sourceRoot, fileName); err == nil && !strings.HasPrefix(relFileName, "..") {
+				fileName = relFileName
+			}
+		}
+		fileName = filepath.ToSlash(fileName)
+
+		// The content, for pragmas and generated file headers, is only known for files under the source root
+		data, _ := os.ReadFile(filepath.Join(sourceRoot, filepath.FromSlash(fileName)))
+
+		if len(file.Lines) == 0 || ignore.Match(fileName, data) {
+			continue
+		}
+
+		pkgName := filepath.ToSlash(filepath.Dir(fileName))
+		pkg := pkgMap[pkgName]
+		if pkg == nil {
+			pkg = &Package{Name: pkgName, Classes: []*Class{}}
+			pkgMap[pkgName] = pkg
+			cov.Packages = append(cov.Packages, pkg)
+		}
+
+		class := file.class(fileName)
+		class.Source = sourceRoot
+		pkg.Classes = append(pkg.Classes, class)
+		pkg.LineRate = pkg.HitRate()
+		pkg.BranchRate = pkg.BranchHitRate()
+	}
+
+	cov.computeRates()
+	return nil
+}
+
+// class converts the file into a class, assigning each line to the last function starting before it
+func (file *LCOVFile) class(fileName string) *Class {
+	class := &Class{Name: fileName, Filename: fileName, Methods: []*Method{}, Lines: []*Line{}}
+
+	branches := make(map[int]*Line)
+	for _, branch := range file.Branches {
+		line := branches[branch.Line]
+		if line == nil {
+			line = &Line{Number: branch.Line}
+			branches[branch.Line] = line
+		}
+		line.Branches++
+		if branch.Taken > 0 {
+			line.BranchesCovered++
+		}
+	}
+
+	lcovLines := make([]*LCOVLine, len(file.Lines))
+	copy(lcovLines, file.Lines)
+	sort.SliceStable(lcovLines, func(i, j int) bool { return lcovLines[i].Number < lcovLines[j].Number })
+
+	for _, lcovLine := range lcovLines {
+		class.Lines.AddOrUpdateLine(lcovLine.Number, lcovLine.Hits)
+		line := class.Lines[len(class.Lines)-1]
+		if branchLine := branches[line.Number]; branchLine != nil {
+			line.SetBranches(branchLine.BranchesCovered, branchLine.Branches)
+		}
+	}
+
+	functions := make([]*LCOVFunction, len(file.Functions))
+	copy(functions, file.Functions)
+	sort.SliceStable(functions, func(i, j int) bool { return functions[i].StartLine < functions[j].StartLine })
+
+	methods := make([]*Method, len(functions))
+	for idx, function := range functions {
+		methods[idx] = &Method{Name: function.Name, Lines: []*Line{}}
+	}
+
+	for _, line := range class.Lines {
+		for idx := len(functions) - 1; idx >= 0; idx-- {
+			function := functions[idx]
+			if function.StartLine <= line.Number && (function.EndLine == 0 || line.Number <= function.EndLine) {
+				methods[idx].Lines = append(methods[idx].Lines, line)
+				break
+			}
+		}
+	}
+
+	for _, method := range methods {
+		if len(method.Lines) > 0 {
+			method.LineRate = method.Lines.HitRate()
+			method.BranchRate = method.Lines.BranchHitRate()
+		}
+		class.Methods = append(class.Methods, method)
+	}
+
+	class.LineRate = class.Lines.HitRate()
+	class.BranchRate = class.Lines.BranchHitRate()
+
+	return class
+}
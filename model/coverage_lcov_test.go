@@ -0,0 +1,91 @@
+package model
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectCoverageFormat(t *testing.T) {
+	if format := DetectCoverageFormat([]byte("TN:\nSF:src/app.ts\n")); format != CoverageFormatLCOV {
+		t.Errorf("expected lcov, got %s", format)
+	}
+
+	if format := DetectCoverageFormat([]byte("mode: set\n")); format != CoverageFormatGo {
+		t.Errorf("expected go, got %s", format)
+	}
+}
+
+func TestParseLCOV(t *testing.T) {
+	f, err := os.Open("testdata/lcov.info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	files, err := ParseLCOV(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cov := Coverage{}
+	if err := cov.ParseLCOV(files, "/home/user/project", &Ignore{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cov.LinesValid != 6 || cov.LinesCovered != 4 {
+		t.Errorf("unexpected lines %d/%d", cov.LinesCovered, cov.LinesValid)
+	}
+
+	if cov.BranchesValid != 2 || cov.BranchesCovered != 1 || cov.BranchRate != 0.5 {
+		t.Errorf("unexpected branches %d/%d", cov.BranchesCovered, cov.BranchesValid)
+	}
+
+	if len(cov.Packages) != 1 || cov.Packages[0].Name != "src" {
+		t.Fatalf("expected the src package, got %+v", cov.Packages)
+	}
+
+	class := cov.Packages[0].Classes[0]
+	if class.Filename != "src/app.ts" || len(class.Methods) != 2 {
+		t.Fatalf("unexpected class %+v", class)
+	}
+
+	if main := class.Methods[0]; main.Name != "main" || main.NumLines() != 3 || main.LineRate != 1 {
+		t.Errorf("unexpected method %+v", main)
+	}
+
+	if line := class.Lines[1]; !line.Branch || line.ConditionCoverage != "50% (1/2)" {
+		t.Errorf("unexpected branch line %+v", line)
+	}
+}
+
+func TestParseLCOVFunctionNames(t *testing.T) {
+	tracefile := `SF:src/sum.cpp
+FN:3,sum<int, int>
+FN:8,12,max<int, long>
+FN:14,twice
+FNDA:2,sum<int, int>
+FNDA:1,max<int, long>
+FNDA:4,twice
+end_of_record
+`
+
+	files, err := ParseLCOV(strings.NewReader(tracefile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || len(files[0].Functions) != 3 {
+		t.Fatalf("unexpected files %+v", files)
+	}
+
+	for idx, expected := range []LCOVFunction{
+		{Name: "sum<int, int>", StartLine: 3, Hits: 2},
+		{Name: "max<int, long>", StartLine: 8, EndLine: 12, Hits: 1},
+		{Name: "twice", StartLine: 14, Hits: 4},
+	} {
+		if function := files[0].Functions[idx]; *function != expected {
+			t.Errorf("expected %+v, got %+v", expected, function)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+)
+
+// Module represents a module of the source checkout, its import path and its directory
+type Module struct {
+	Path string
+	Dir  string
+}
+
+// FindModules returns the modules of the source root, the ones used by its go.work file when
+// there is one, or the module of its go.mod file otherwise. The go toolchain is not involved.
+func FindModules(sourceRoot string) ([]*Module, error) {
+	sourceRoot, err := filepath.Abs(sourceRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{sourceRoot}
+	if workData, err := os.ReadFile(filepath.Join(sourceRoot, "go.work")); err == nil {
+		dirs, err = parseWorkUses(workData)
+		if err != nil {
+			return nil, err
+		}
+		for idx, dir := range dirs {
+			if !filepath.IsAbs(dir) {
+				dirs[idx] = filepath.Join(sourceRoot, dir)
+			}
+		}
+	}
+
+	modules := make([]*Module, 0, len(dirs))
+	for _, dir := range dirs {
+		modData, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+
+		modulePath := modfile.ModulePath(modData)
+		if modulePath == "" {
+			return nil, fmt.Errorf("no module path in %s", filepath.Join(dir, "go.mod"))
+		}
+		modules = append(modules, &Module{Path: modulePath, Dir: filepath.Clean(dir)})
+	}
+
+	return modules, nil
+}
+
+// parseWorkUses returns the directories of the use directives of a go.work file,
+// either "use ./dir" or a "use ( ... )" block
+func parseWorkUses(data []byte) ([]string, error) {
+	dirs := make([]string, 0)
+	inBlock := false
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := s.Text()
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case line == "use (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "use "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "use "))
+		default:
+			continue
+		}
+
+		dir := line
+		if strings.HasPrefix(dir, `"`) || strings.HasPrefix(dir, "`") {
+			unquoted, err := strconv.Unquote(dir)
+			if err != nil {
+				return nil, fmt.Errorf("bad use directive in go.work: %s", line)
+			}
+			dir = unquoted
+		}
+		dirs = append(dirs, dir)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return dirs, nil
+}
+
+// ResolvePackages maps the packages of the profiles to directories of the modules, as packages.Load
+// would, by their import paths. The longest module path wins, as modules may be nested.
+func ResolvePackages(profiles []*Profile, modules []*Module) ([]*packages.Package, error) {
+	sortedModules := make([]*Module, len(modules))
+	copy(sortedModules, modules)
+	sort.SliceStable(sortedModules, func(i, j int) bool { return len(sortedModules[i].Path) > len(sortedModules[j].Path) })
+
+	pkgs := make([]*packages.Package, 0)
+	seen := make(map[string]bool)
+	for _, profile := range profiles {
+		pkgName := getPackageName(profile.FileName)
+		if seen[pkgName] {
+			continue
+		}
+		seen[pkgName] = true
+
+		module := findModule(sortedModules, pkgName)
+		if module == nil {
+			return nil, fmt.Errorf("no module of the source root provides package %s", pkgName)
+		}
+
+		dir := filepath.Join(module.Dir, filepath.FromSlash(strings.TrimPrefix(pkgName, module.Path)))
+		goFiles, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+
+		pkgs = append(pkgs, &packages.Package{
+			ID:      pkgName,
+			PkgPath: pkgName,
+			GoFiles: goFiles,
+			Module:  &packages.Module{Path: module.Path, Dir: module.Dir},
+		})
+	}
+
+	return pkgs, nil
+}
+
+// findModule returns the module providing the package, modules must be sorted by decreasing path length
+func findModule(modules []*Module, pkgName string) *Module {
+	for _, module := range modules {
+		if pkgName == module.Path || strings.HasPrefix(pkgName, module.Path+"/") {
+			return module
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindModules(t *testing.T) {
+	modules, err := FindModules("testdata/workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, _ := filepath.Abs("testdata/workspace")
+	expected := []Module{
+		{Path: "example.com/app", Dir: filepath.Join(root, "app")},
+		{Path: "example.com/lib", Dir: filepath.Join(root, "lib")},
+	}
+	if len(modules) != len(expected) {
+		t.Fatalf("expected %d modules, got %d", len(expected), len(modules))
+	}
+	for idx, module := range modules {
+		if *module != expected[idx] {
+			t.Errorf("expected module %+v, got %+v", expected[idx], *module)
+		}
+	}
+
+	modules, err = FindModules("testdata/workspace/lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 1 || modules[0].Path != "example.com/lib" {
+		t.Errorf("expected the module of go.mod, got %+v", modules)
+	}
+}
+
+func TestResolvePackages(t *testing.T) {
+	profile := `mode: set
+example.com/app/main.go:5.13,7.2 1 1
+example.com/lib/util/util.go:4.24,5.11 1 1
+`
+	profiles, err := ParseProfiles(strings.NewReader(profile), &Ignore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := FindModules("testdata/workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, err := ResolvePackages(profiles, modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pkgs) != 2 || pkgs[0].ID != "example.com/app" || pkgs[1].ID != "example.com/lib/util" {
+		t.Fatalf("unexpected packages %+v", pkgs)
+	}
+	if len(pkgs[1].GoFiles) != 1 || filepath.Base(pkgs[1].GoFiles[0]) != "util.go" || pkgs[1].Module.Path != "example.com/lib" {
+		t.Errorf("unexpected package %+v", pkgs[1])
+	}
+
+	if _, err := ResolvePackages(profiles, modules[:1]); err == nil {
+		t.Error("expected an error for a package outside of the modules")
+	}
+}
+
+func TestParseProfilesWorkspace(t *testing.T) {
+	profile := `mode: set
+example.com/app/util/util.go:4.32,6.2 1 1
+example.com/lib/util/util.go:4.24,5.11 1 1
+example.com/lib/util/util.go:5.11,7.3 1 0
+example.com/lib/util/util.go:8.2,8.10 1 1
+`
+	profiles, err := ParseProfiles(strings.NewReader(profile), &Ignore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := FindModules("testdata/workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, err := ResolvePackages(profiles, modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgMap := make(map[string]*packages.Package)
+	for _, pkg := range pkgs {
+		pkgMap[pkg.ID] = pkg
+	}
+
+	cov := Coverage{}
+	if err := cov.ParseProfiles(profiles, pkgMap, &Ignore{}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cov.Sources) != 2 || cov.Sources[0].Path != modules[0].Dir || cov.Sources[1].Path != modules[1].Dir {
+		t.Errorf("expected a source per module, got %+v", cov.Sources)
+	}
+
+	expected := map[string]string{
+		"example.com/app/util": "testdata/workspace/app/util/util.go",
+		"example.com/lib/util": "testdata/workspace/lib/util/util.go",
+	}
+	if len(cov.Packages) != len(expected) {
+		t.Fatalf("expected %d packages, got %d", len(expected), len(cov.Packages))
+	}
+	for _, pkg := range cov.Packages {
+		class := pkg.Classes[0]
+		if class.Filename != "util/util.go" || class.Path() != expected[pkg.Name] {
+			t.Errorf("%s: unexpected file name %s and path %s", pkg.Name, class.Filename, class.Path())
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Comments excluding code from the coverage report, an explanation may follow the pragma.
+//
+//	//coverage:ignore         on a function, or on the line of its declaration
+//	//coverage:ignore-start   up to the matching //coverage:ignore-end, or the end of the file
+//	//coverage:ignore-file    anywhere in a file
+const (
+	CoveragePragmaIgnore      = "coverage:ignore"
+	CoveragePragmaIgnoreStart = "coverage:ignore-start"
+	CoveragePragmaIgnoreEnd   = "coverage:ignore-end"
+	CoveragePragmaIgnoreFile  = "coverage:ignore-file"
+)
+
+var ignoreFileRe = regexp.MustCompile(`(?m)^\s*//\s*coverage:ignore-file(?:\s|$)`)
+
+// lineRange is an inclusive range of lines
+type lineRange struct {
+	start int
+	end   int
+}
+
+// coveragePragma returns the coverage pragma of a comment, if any
+func coveragePragma(comment *ast.Comment) string {
+	text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+	if !strings.HasPrefix(text, "coverage:") {
+		return ""
+	}
+
+	return strings.Fields(text)[0]
+}
+
+// coveragePragmas returns the coverage pragmas of a file by line
+func coveragePragmas(fset *token.FileSet, file *ast.File) map[int]string {
+	pragmas := make(map[int]string)
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if pragma := coveragePragma(comment); pragma != "" {
+				pragmas[fset.Position(comment.Pos()).Line] = pragma
+			}
+		}
+	}
+	return pragmas
+}
+
+// ignoredRanges returns the ranges of lines between the ignore-start and ignore-end pragmas
+func ignoredRanges(pragmas map[int]string) []lineRange {
+	lines := make([]int, 0, len(pragmas))
+	for line := range pragmas {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+
+	ranges := make([]lineRange, 0)
+	start := 0
+	for _, line := range lines {
+		switch pragmas[line] {
+		case CoveragePragmaIgnoreStart:
+			if start == 0 {
+				start = line
+			}
+		case CoveragePragmaIgnoreEnd:
+			if start != 0 {
+				ranges = append(ranges, lineRange{start: start, end: line})
+				start = 0
+			}
+		}
+	}
+
+	if start != 0 {
+		ranges = append(ranges, lineRange{start: start, end: math.MaxInt32})
+	}
+
+	return ranges
+}
+
+// isIgnoredFunc reports whether the function has an ignore pragma in its doc comment or on its first line,
+// or lies between ignore-start and ignore-end pragmas
+func (v *fileVisitor) isIgnoredFunc(n *ast.FuncDecl) bool {
+	if v.isIgnoredLine(v.fset.Position(n.Pos()).Line) && v.isIgnoredLine(v.fset.Position(n.End()).Line) {
+		return true
+	}
+
+	if n.Doc != nil {
+		for _, comment := range n.Doc.List {
+			if coveragePragma(comment) == CoveragePragmaIgnore {
+				return true
+			}
+		}
+	}
+
+	return v.pragmas[v.fset.Position(n.Pos()).Line] == CoveragePragmaIgnore
+}
+
+// isIgnoredLine reports whether the line is between ignore-start and ignore-end pragmas
+func (v *fileVisitor) isIgnoredLine(line int) bool {
+	for _, r := range v.ignored {
+		if r.start <= line && line <= r.end {
+			return true
+		}
+	}
+	return false
+}
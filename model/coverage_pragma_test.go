@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+)
+
+const pragmaProfile = `mode: set
+pragma.go:7.2,7.12 1 1
+pragma.go:8.3,9.1 1 0
+pragma.go:11.2,11.11 1 1
+pragma.go:12.3,13.1 1 0
+pragma.go:15.2,15.19 1 1
+pragma.go:22.2,23.16 2 0
+pragma.go:24.3,24.13 1 0
+pragma.go:26.2,26.15 1 0
+pragma.go:30.2,31.1 1 0
+`
+
+func TestCoveragePragmas(t *testing.T) {
+	pkg := visitTestdata(t, "testdata/pragma/pragma.go", pragmaProfile)
+
+	methods := pkg.AllMethods()
+	if len(methods) != 1 || methods[0].Name != "Divide" {
+		t.Fatalf("expected the ignored functions to be dropped, got %d methods", len(methods))
+	}
+
+	lines := make([]int, 0)
+	for _, line := range methods[0].Lines {
+		lines = append(lines, line.Number)
+	}
+	if FormatLineRanges(lines) != "7-9, 15" {
+		t.Errorf("expected the lines between ignore-start and ignore-end to be dropped, got %v", lines)
+	}
+}
+
+func TestIgnoreFilePragma(t *testing.T) {
+	ignore := &Ignore{}
+	if !ignore.Match("shim.go", []byte("// Package shim wraps the platform API\n//coverage:ignore-file\npackage shim\n")) {
+		t.Error("expected a file with the ignore-file pragma to be ignored")
+	}
+
+	if ignore.Match("other.go", []byte("package other\n\n// See coverage:ignore-file in the README\n")) {
+		t.Error("expected a mention of the pragma not to ignore the file")
+	}
+}
@@ -34,50 +34,28 @@ func (p byFileName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 // ParseProfiles parses profile data from the given Reader and returns a
 // Profile for each file.
 func ParseProfiles(in io.Reader, ignore *Ignore) ([]*Profile, error) {
+	return MergeProfiles([]io.Reader{in}, ignore)
+}
+
+// MergeProfiles parses the profile data of several Readers, e.g. from parallel test jobs,
+// and returns a Profile for each file. Every input must use the same mode, the counts of
+// blocks found in several inputs are or-ed in set mode and summed in count and atomic modes.
+func MergeProfiles(ins []io.Reader, ignore *Ignore) ([]*Profile, error) {
 	files := make(map[string]*Profile)
-	// First line is "mode: foo", where foo is "set", "count", or "atomic".
-	// Rest of file is in the format
-	//      encoding/base64/base64.go:34.44,37.40 3 1
-	// where the fields are: name.go:line.column,line.column numberOfStatements count
-	s := bufio.NewScanner(in)
 	mode := ""
-	for s.Scan() {
-		line := s.Text()
-		if mode == "" {
-			const p = "mode: "
-			if !strings.HasPrefix(line, p) || line == p {
-				return nil, fmt.Errorf("bad mode line: %v", line)
-			}
-			mode = line[len(p):]
-			continue
+	for idx, in := range ins {
+		inputMode, err := readProfile(in, files, ignore)
+		if err != nil {
+			return nil, err
 		}
-		m := lineRe.FindStringSubmatch(line)
-		if m == nil {
+		if inputMode == "" {
+			// An empty profile, e.g. from a job without tests
 			continue
 		}
-		fn := m[1]
-		if ignore.Match(fn, nil) {
-			continue
-		}
-		p := files[fn]
-		if p == nil {
-			p = &Profile{
-				FileName: fn,
-				Mode:     mode,
-			}
-			files[fn] = p
+		if mode != "" && inputMode != mode {
+			return nil, fmt.Errorf("inconsistent mode: input %d uses %q, expected %q", idx+1, inputMode, mode)
 		}
-		p.Blocks = append(p.Blocks, ProfileBlock{
-			StartLine: toInt(m[2]),
-			StartCol:  toInt(m[3]),
-			EndLine:   toInt(m[4]),
-			EndCol:    toInt(m[5]),
-			NumStmt:   toInt(m[6]),
-			Count:     toInt(m[7]),
-		})
-	}
-	if err := s.Err(); err != nil {
-		return nil, err
+		mode = inputMode
 	}
 
 	for _, p := range files {
@@ -116,6 +94,60 @@ func ParseProfiles(in io.Reader, ignore *Ignore) ([]*Profile, error) {
 	return profiles, nil
 }
 
+// readProfile adds the blocks of a profile to files and returns its mode.
+// Profiles concatenated into a single input repeat the mode line, which must not change.
+func readProfile(in io.Reader, files map[string]*Profile, ignore *Ignore) (string, error) {
+	// First line is "mode: foo", where foo is "set", "count", or "atomic".
+	// Rest of file is in the format
+	//      encoding/base64/base64.go:34.44,37.40 3 1
+	// where the fields are: name.go:line.column,line.column numberOfStatements count
+	const modePrefix = "mode: "
+	s := bufio.NewScanner(in)
+	mode := ""
+	for s.Scan() {
+		line := s.Text()
+		if mode == "" || strings.HasPrefix(line, modePrefix) {
+			if !strings.HasPrefix(line, modePrefix) || line == modePrefix {
+				return "", fmt.Errorf("bad mode line: %v", line)
+			}
+			if mode != "" && line[len(modePrefix):] != mode {
+				return "", fmt.Errorf("inconsistent mode: %q changed to %q", mode, line[len(modePrefix):])
+			}
+			mode = line[len(modePrefix):]
+			continue
+		}
+		m := lineRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		fn := m[1]
+		if ignore.Match(fn, nil) {
+			continue
+		}
+		p := files[fn]
+		if p == nil {
+			p = &Profile{
+				FileName: fn,
+				Mode:     mode,
+			}
+			files[fn] = p
+		}
+		p.Blocks = append(p.Blocks, ProfileBlock{
+			StartLine: toInt(m[2]),
+			StartCol:  toInt(m[3]),
+			EndLine:   toInt(m[4]),
+			EndCol:    toInt(m[5]),
+			NumStmt:   toInt(m[6]),
+			Count:     toInt(m[7]),
+		})
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+
+	return mode, nil
+}
+
 type blocksByStart []ProfileBlock
 
 func (b blocksByStart) Len() int      { return len(b) }
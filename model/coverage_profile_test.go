@@ -0,0 +1,67 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMergeProfiles(t *testing.T) {
+	tests := []struct {
+		mode   string
+		first  [2]string
+		second [2]string
+		counts []int
+	}{
+		{"set", [2]string{"1", "0"}, [2]string{"1", "1"}, []int{1, 1, 0}},
+		{"count", [2]string{"3", "0"}, [2]string{"2", "2"}, []int{5, 2, 0}},
+		{"atomic", [2]string{"3", "0"}, [2]string{"2", "2"}, []int{5, 2, 0}},
+	}
+
+	for _, test := range tests {
+		first := "mode: " + test.mode + "\n" +
+			"pkg/a.go:3.10,5.2 2 " + test.first[0] + "\n" +
+			"pkg/a.go:7.10,9.2 1 " + test.first[1] + "\n"
+		second := "mode: " + test.mode + "\n" +
+			"pkg/a.go:3.10,5.2 2 " + test.second[0] + "\n" +
+			"pkg/a.go:7.10,9.2 1 " + test.second[1] + "\n" +
+			"pkg/b.go:1.1,2.2 1 0\n"
+
+		profiles, err := MergeProfiles([]io.Reader{strings.NewReader(first), strings.NewReader(second)}, &Ignore{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(profiles) != 2 || profiles[0].FileName != "pkg/a.go" || profiles[1].FileName != "pkg/b.go" {
+			t.Fatalf("%s: unexpected profiles %+v", test.mode, profiles)
+		}
+
+		counts := []int{profiles[0].Blocks[0].Count, profiles[0].Blocks[1].Count, profiles[1].Blocks[0].Count}
+		for idx, count := range counts {
+			if count != test.counts[idx] {
+				t.Errorf("%s: expected counts %v, got %v", test.mode, test.counts, counts)
+				break
+			}
+		}
+	}
+}
+
+func TestMergeProfilesInconsistentMode(t *testing.T) {
+	ins := []io.Reader{
+		strings.NewReader("mode: set\npkg/a.go:3.10,5.2 2 1\n"),
+		strings.NewReader("mode: count\npkg/a.go:3.10,5.2 2 4\n"),
+	}
+	if _, err := MergeProfiles(ins, &Ignore{}); err == nil {
+		t.Error("expected an error for inputs with different modes")
+	}
+
+	concatenated := "mode: set\npkg/a.go:3.10,5.2 2 1\nmode: atomic\npkg/a.go:3.10,5.2 2 4\n"
+	if _, err := ParseProfiles(strings.NewReader(concatenated), &Ignore{}); err == nil {
+		t.Error("expected an error for a mode changing within an input")
+	}
+
+	ins = []io.Reader{strings.NewReader(""), strings.NewReader("mode: set\npkg/a.go:3.10,5.2 2 1\n")}
+	if profiles, err := MergeProfiles(ins, &Ignore{}); err != nil || len(profiles) != 1 {
+		t.Errorf("expected empty inputs to be skipped, got %v", err)
+	}
+}
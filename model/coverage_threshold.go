@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ThresholdTotal   = "total"
+	ThresholdPackage = "package"
+	ThresholdFile    = "file"
+)
+
+// CoverageThreshold holds the minimum line coverage percentages, a zero value disables a check
+type CoverageThreshold struct {
+	MinTotal         float64
+	MinPackage       float64
+	MinFile          float64
+	PackageOverrides []*PackageThreshold
+}
+
+// PackageThreshold overrides the minimum package coverage for packages matching a glob.
+// As path.Match, "*" doesn't match "/", a pattern ending with "/..." matches the nested packages as well.
+type PackageThreshold struct {
+	Pattern string
+	Min     float64
+}
+
+// ThresholdViolation describes an element whose coverage is below its threshold
+type ThresholdViolation struct {
+	Kind      string
+	Name      string
+	Coverage  float64
+	Threshold float64
+}
+
+// ParsePackageThreshold parses a "glob=percentage" package override, e.g. "github.com/org/repo/internal/...=60"
+func ParsePackageThreshold(value string) (*PackageThreshold, error) {
+	idx := strings.LastIndex(value, "=")
+	if idx <= 0 {
+		return nil, fmt.Errorf("bad package threshold %q, must be glob=percentage", value)
+	}
+
+	pattern := value[:idx]
+	if _, err := path.Match(strings.TrimSuffix(pattern, "/..."), ""); err != nil {
+		return nil, fmt.Errorf("bad package threshold glob %q: %v", pattern, err)
+	}
+
+	min, err := strconv.ParseFloat(value[idx+1:], 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad package threshold percentage %q: %v", value[idx+1:], err)
+	}
+
+	return &PackageThreshold{Pattern: pattern, Min: min}, nil
+}
+
+// Match reports whether the package matches the pattern of the override
+func (p *PackageThreshold) Match(pkgName string) bool {
+	pattern := p.Pattern
+	if strings.HasSuffix(pattern, "/...") {
+		// The glob applies to as many leading elements of the package as it has
+		pattern = strings.TrimSuffix(pattern, "/...")
+		elements := strings.Split(pkgName, "/")
+		if n := strings.Count(pattern, "/") + 1; n < len(elements) {
+			pkgName = strings.Join(elements[:n], "/")
+		}
+	}
+
+	match, _ := path.Match(pattern, pkgName)
+	return match
+}
+
+// packageMin returns the minimum coverage of a package, using the first matching override
+// in the order they were given
+func (t *CoverageThreshold) packageMin(pkgName string) float64 {
+	for _, override := range t.PackageOverrides {
+		if override.Match(pkgName) {
+			return override.Min
+		}
+	}
+
+	return t.MinPackage
+}
+
+// Check returns the elements of the coverage report below their threshold
+func (t *CoverageThreshold) Check(cov *Coverage) []*ThresholdViolation {
+	violations := make([]*ThresholdViolation, 0)
+
+	check := func(kind string, name string, numLinesWithHits int64, numLines int64, min float64) {
+		if min <= 0 || numLines == 0 {
+			return
+		}
+
+		percentage := 100 * float64(numLinesWithHits) / float64(numLines)
+		if percentage < min {
+			violations = append(violations, &ThresholdViolation{Kind: kind, Name: name, Coverage: percentage, Threshold: min})
+		}
+	}
+
+	check(ThresholdTotal, ThresholdTotal, cov.NumLinesWithHits(), cov.NumLines(), t.MinTotal)
+
+	for _, pkg := range cov.Packages {
+		check(ThresholdPackage, pkg.Name, pkg.NumLinesWithHits(), pkg.NumLines(), t.packageMin(pkg.Name))
+	}
+
+	if t.MinFile > 0 {
+		// Classes are grouped by receiver, a file may hold several of them
+		fileLines := make(map[string]int64)
+		fileLinesWithHits := make(map[string]int64)
+		for _, pkg := range cov.Packages {
+			for _, class := range pkg.Classes {
+				fileLines[class.Path()] += class.NumLines()
+				fileLinesWithHits[class.Path()] += class.NumLinesWithHits()
+			}
+		}
+
+		fileNames := make([]string, 0, len(fileLines))
+		for fileName := range fileLines {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+
+		for _, fileName := range fileNames {
+			check(ThresholdFile, fileName, fileLinesWithHits[fileName], fileLines[fileName], t.MinFile)
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestParsePackageThreshold(t *testing.T) {
+	tests := []struct {
+		value   string
+		pattern string
+		min     float64
+		valid   bool
+	}{
+		{"github.com/org/repo/internal/*=60", "github.com/org/repo/internal/*", 60, true},
+		{"github.com/org/repo/internal/...=40.5", "github.com/org/repo/internal/...", 40.5, true},
+		{"github.com/org/repo/gen=0", "github.com/org/repo/gen", 0, true},
+		{"github.com/org/repo", "", 0, false},
+		{"=60", "", 0, false},
+		{"github.com/org/repo=", "", 0, false},
+		{"github.com/org/repo=high", "", 0, false},
+		{"github.com/org/[repo=60", "", 0, false},
+	}
+
+	for _, test := range tests {
+		threshold, err := ParsePackageThreshold(test.value)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.value)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %v", test.value, err)
+			continue
+		}
+
+		if threshold.Pattern != test.pattern || threshold.Min != test.min {
+			t.Errorf("%s: unexpected threshold %+v", test.value, threshold)
+		}
+	}
+}
+
+func TestPackageMin(t *testing.T) {
+	threshold := &CoverageThreshold{MinPackage: 60}
+	for _, value := range []string{"example.com/app/internal/gen=0", "example.com/app/internal/*=40", "example.com/app/legacy/...=20"} {
+		override, err := ParsePackageThreshold(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		threshold.PackageOverrides = append(threshold.PackageOverrides, override)
+	}
+
+	tests := []struct {
+		pkgName string
+		min     float64
+	}{
+		// The first matching override wins
+		{"example.com/app/internal/gen", 0},
+		{"example.com/app/internal/store", 40},
+		// A star doesn't match nested packages
+		{"example.com/app/internal/store/sql", 60},
+		{"example.com/app/legacy", 20},
+		{"example.com/app/legacy/v1/api", 20},
+		{"example.com/app/legacyapi", 60},
+		{"example.com/app", 60},
+	}
+
+	for _, test := range tests {
+		if min := threshold.packageMin(test.pkgName); min != test.min {
+			t.Errorf("%s: expected a minimum of %v, got %v", test.pkgName, test.min, min)
+		}
+	}
+}
+
+func newThresholdPackage(name string, fileName string, hits ...int64) *Package {
+	lines := make(Lines, 0, len(hits))
+	for idx, hit := range hits {
+		lines = append(lines, &Line{Number: idx + 1, Hits: hit})
+	}
+
+	method := &Method{Name: "-", Lines: lines}
+	return &Package{Name: name, Classes: []*Class{{Name: "-", Filename: fileName, Methods: []*Method{method}, Lines: lines}}}
+}
+
+func TestCoverageThresholdCheck(t *testing.T) {
+	cov := &Coverage{Packages: []*Package{
+		newThresholdPackage("example.com/app", "main.go", 1, 1, 1, 0),
+		newThresholdPackage("example.com/app/internal/gen", "internal/gen/gen.go", 0, 0, 0, 1),
+		newThresholdPackage("example.com/app/empty", "empty/empty.go"),
+	}}
+
+	threshold := &CoverageThreshold{MinTotal: 80, MinPackage: 50, MinFile: 30}
+	override, _ := ParsePackageThreshold("example.com/app/internal/...=0")
+	threshold.PackageOverrides = []*PackageThreshold{override}
+
+	violations := threshold.Check(cov)
+
+	// The total is at 50%, the generated package is only checked as a file as its override is 0,
+	// the package without lines is skipped
+	expected := []struct {
+		kind string
+		name string
+	}{
+		{ThresholdTotal, ThresholdTotal},
+		{ThresholdFile, "internal/gen/gen.go"},
+	}
+
+	if len(violations) != len(expected) {
+		t.Fatalf("expected %d violations, got %+v", len(expected), violations)
+	}
+
+	for idx, violation := range violations {
+		if violation.Kind != expected[idx].kind || violation.Name != expected[idx].name {
+			t.Errorf("unexpected violation %+v", violation)
+		}
+	}
+
+	if violations := (&CoverageThreshold{}).Check(cov); len(violations) != 0 {
+		t.Errorf("expected no violation without thresholds, got %+v", violations)
+	}
+}
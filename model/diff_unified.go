@@ -0,0 +1,198 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diff holds the lines added or changed in each file of a unified diff
+type Diff struct {
+	Files map[string]*FileDiff
+}
+
+// FileDiff holds the added or changed lines of a file, numbered as in the new version of the file
+type FileDiff struct {
+	Path  string
+	Lines map[int]bool
+}
+
+var hunkRe = regexp.MustCompile(`^@@ -[0-9]+(?:,([0-9]+))? \+([0-9]+)(?:,([0-9]+))? @@`)
+
+// ParseUnifiedDiff parses the output of git diff, or any unified diff, and returns the changed lines per file.
+// Deleted files are skipped since there is nothing left to report on.
+func ParseUnifiedDiff(in io.Reader) (*Diff, error) {
+	diff := &Diff{Files: make(map[string]*FileDiff)}
+
+	var file *FileDiff
+	// Position in the new file and lines left in the current hunk
+	newLine, oldRemaining, newRemaining := 0, 0, 0
+
+	s := bufio.NewScanner(in)
+	s.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for s.Scan() {
+		line := s.Text()
+
+		if oldRemaining > 0 || newRemaining > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if file != nil {
+					file.Lines[newLine] = true
+				}
+				newLine++
+				newRemaining--
+			case strings.HasPrefix(line, "-"):
+				oldRemaining--
+			case strings.HasPrefix(line, "\\"):
+				// \ No newline at end of file
+			default:
+				newLine++
+				oldRemaining--
+				newRemaining--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			path := diffPath(line[len("+++ "):])
+			if path == "" {
+				file = nil
+				continue
+			}
+			file = diff.Files[path]
+			if file == nil {
+				file = &FileDiff{Path: path, Lines: make(map[int]bool)}
+				diff.Files[path] = file
+			}
+		case strings.HasPrefix(line, "@@"):
+			m := hunkRe.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("bad hunk header: %v", line)
+			}
+			oldRemaining, newLine, newRemaining = hunkCount(m[1]), toInt(m[2]), hunkCount(m[3])
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// hunkCount returns the number of lines of a hunk range, which defaults to one when omitted
+func hunkCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	return toInt(count)
+}
+
+// diffPath returns the path of a "+++" header without its "b/" prefix, or an empty string for deleted files
+func diffPath(header string) string {
+	// Strip the timestamp appended by diff -u
+	if idx := strings.Index(header, "\t"); idx >= 0 {
+		header = header[:idx]
+	}
+
+	// Paths with special characters are C-quoted by git, e.g. "b/caf\303\251.go"
+	if strings.HasPrefix(header, `"`) {
+		if unquoted, err := strconv.Unquote(header); err == nil {
+			header = unquoted
+		} else {
+			header = strings.Trim(header, `"`)
+		}
+	}
+	if header == "/dev/null" {
+		return ""
+	}
+
+	return filepath.ToSlash(filepath.Clean(strings.TrimPrefix(header, "b/")))
+}
+
+// File returns the changes of a file. Paths are matched on their trailing components, since reports
+// may use absolute paths or paths relative to a module while the diff is relative to the repository root.
+// The file sharing the most trailing components wins, no file is returned when several of them share as many.
+func (d *Diff) File(path string) *FileDiff {
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	if file, exists := d.Files[path]; exists {
+		return file
+	}
+
+	var match *FileDiff
+	matchLength, ambiguous := 0, false
+	for diffPath, file := range d.Files {
+		if !strings.HasSuffix(path, "/"+diffPath) && !strings.HasSuffix(diffPath, "/"+path) {
+			continue
+		}
+
+		// The shorter path is the common suffix
+		length := len(diffPath)
+		if len(path) < length {
+			length = len(path)
+		}
+
+		switch {
+		case length > matchLength:
+			match, matchLength, ambiguous = file, length, false
+		case length == matchLength:
+			ambiguous = true
+		}
+	}
+
+	if ambiguous {
+		return nil
+	}
+
+	return match
+}
+
+// Contains reports whether the line was added or changed
+func (f *FileDiff) Contains(line int) bool {
+	return f.Lines[line]
+}
+
+// Intersects reports whether any line between begin and end, inclusive, was added or changed
+func (f *FileDiff) Intersects(begin int, end int) bool {
+	if end < begin {
+		end = begin
+	}
+
+	for line := begin; line <= end; line++ {
+		if f.Lines[line] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatLineRanges formats sorted line numbers as ranges, e.g. "3-5, 9"
+func FormatLineRanges(lines []int) string {
+	sortedLines := make([]int, len(lines))
+	copy(sortedLines, lines)
+	sort.Ints(sortedLines)
+
+	ranges := make([]string, 0)
+	for i := 0; i < len(sortedLines); {
+		j := i
+		for j+1 < len(sortedLines) && sortedLines[j+1] == sortedLines[j]+1 {
+			j++
+		}
+
+		if i == j {
+			ranges = append(ranges, fmt.Sprintf("%d", sortedLines[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", sortedLines[i], sortedLines[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(ranges, ", ")
+}
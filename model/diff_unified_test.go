@@ -0,0 +1,160 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+const unifiedDiff = `diff --git a/model/foo.go b/model/foo.go
+index 1111111..2222222 100644
+--- a/model/foo.go
++++ b/model/foo.go
+@@ -3,2 +3,3 @@ func Foo() {
+ 	a := 1
+-	b := 2
++	b := 3
++	c := 4
+@@ -10,0 +12 @@ func Bar() {
++	return
+diff --git a/model/old.go b/model/old.go
+deleted file mode 100644
+--- a/model/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package model
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff, err := ParseUnifiedDiff(strings.NewReader(unifiedDiff))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(diff.Files))
+	}
+
+	file := diff.File("/home/user/repo/model/foo.go")
+	if file == nil {
+		t.Fatal("expected the file to match by suffix")
+	}
+
+	for _, line := range []int{4, 5, 12} {
+		if !file.Contains(line) {
+			t.Errorf("expected line %d to be changed", line)
+		}
+	}
+
+	if file.Contains(3) || file.Contains(6) {
+		t.Error("expected context lines to be unchanged")
+	}
+
+	if !file.Intersects(1, 4) || file.Intersects(6, 11) {
+		t.Error("unexpected intersection")
+	}
+}
+
+func TestDiffFileSuffix(t *testing.T) {
+	diff := &Diff{Files: map[string]*FileDiff{
+		"app/main.go":        {},
+		"lib/main.go":        {},
+		"app/util/util.go":   {},
+		"lib/util/util.go":   {},
+		"lib/store/sql.go":   {},
+		"tools/store/sql.go": {},
+	}}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/work/repo/app/main.go", "app/main.go"},
+		// Several files share as many trailing components
+		{"main.go", ""},
+		{"util/util.go", ""},
+		{"lib/util/util.go", "lib/util/util.go"},
+		// The file sharing the most trailing components wins
+		{"/work/repo/tools/store/sql.go", "tools/store/sql.go"},
+		{"other.go", ""},
+	}
+
+	for _, test := range tests {
+		file := diff.File(test.path)
+		if test.expected == "" {
+			if file != nil {
+				t.Errorf("%s: expected no file", test.path)
+			}
+			continue
+		}
+
+		if file != diff.Files[test.expected] {
+			t.Errorf("%s: expected %s", test.path, test.expected)
+		}
+	}
+}
+
+func TestParseUnifiedDiffQuotedPath(t *testing.T) {
+	quotedDiff := "diff --git \"a/caf\\303\\251 menu.go\" \"b/caf\\303\\251 menu.go\"\n" +
+		"--- \"a/caf\\303\\251 menu.go\"\n" +
+		"+++ \"b/caf\\303\\251 menu.go\"\n" +
+		"@@ -1,0 +2 @@\n" +
+		"+// Menu\n"
+
+	diff, err := ParseUnifiedDiff(strings.NewReader(quotedDiff))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file := diff.File("café menu.go"); file == nil || !file.Contains(2) {
+		t.Errorf("expected the unquoted path, got %v", diff.Files)
+	}
+}
+
+func TestDiffCoverage(t *testing.T) {
+	diff, _ := ParseUnifiedDiff(strings.NewReader(unifiedDiff))
+
+	cov := &Coverage{Packages: []*Package{{Classes: []*Class{{
+		Filename: "foo.go",
+		Lines:    Lines{{Number: 3, Hits: 1}, {Number: 4, Hits: 1}, {Number: 5, Hits: 0}, {Number: 12, Hits: 0}},
+	}}}}}
+
+	diffCoverage := cov.DiffCoverage(diff)
+	if diffCoverage.NumLines() != 3 || diffCoverage.NumLinesWithHits() != 1 {
+		t.Errorf("unexpected diff coverage %d/%d", diffCoverage.NumLinesWithHits(), diffCoverage.NumLines())
+	}
+
+	if ranges := FormatLineRanges(diffCoverage.Files[0].Uncovered); ranges != "5, 12" {
+		t.Errorf("unexpected uncovered lines %q", ranges)
+	}
+}
+
+func TestFormatLineRanges(t *testing.T) {
+	if ranges := FormatLineRanges([]int{9, 3, 4, 5}); ranges != "3-5, 9" {
+		t.Errorf("unexpected ranges %q", ranges)
+	}
+}
+
+func TestFilterReportsByDiff(t *testing.T) {
+	diff, _ := ParseUnifiedDiff(strings.NewReader(unifiedDiff))
+
+	newLocatedReport := func(path string, begin int, end int) *Report {
+		return &Report{Location: ReportLocation{Path: path, Positions: ReportLocationPositions{
+			Begin: ReportLocationPositionsData{Line: begin},
+			End:   ReportLocationPositionsData{Line: end},
+		}}}
+	}
+
+	reports := []*Report{
+		newLocatedReport("model/foo.go", 2, 4),
+		newLocatedReport("model/foo.go", 8, 8),
+		newLocatedReport("model/bar.go", 4, 4),
+	}
+
+	if filtered := FilterReportsByDiff(reports, diff, false); len(filtered) != 1 || filtered[0] != reports[0] {
+		t.Errorf("expected only the report on changed lines, got %d reports", len(filtered))
+	}
+
+	if filtered := FilterReportsByDiff(reports, diff, true); len(filtered) != 2 {
+		t.Errorf("expected every report of the changed file, got %d reports", len(filtered))
+	}
+}
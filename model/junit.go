@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// JUnitTestSuites represents the root of a JUnit XML report.
+// <testsuites><testsuite ...><testcase ... /></testsuite></testsuites>
+//
+// References:
+// https://docs.gitlab.com/ee/ci/testing/unit_test_reports.html
+// https://github.com/testmoapp/junitxml
+type JUnitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite represents <testsuite name="pkg" tests="1" ...>...</testsuite>
+type JUnitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr,omitempty"`
+	TestCases []*JUnitTestCase `xml:"testcase"`
+	SystemOut string           `xml:"system-out,omitempty"`
+}
+
+// JUnitTestCase represents <testcase name="TestFoo" classname="pkg" time="0.010" />
+type JUnitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	Classname string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *JUnitResult `xml:"failure,omitempty"`
+	Error     *JUnitResult `xml:"error,omitempty"`
+	Skipped   *JUnitResult `xml:"skipped,omitempty"`
+	SystemOut string       `xml:"system-out,omitempty"`
+}
+
+// JUnitResult represents the <failure>, <error> or <skipped> element of a test case
+type JUnitResult struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr,omitempty"`
+	Contents string `xml:",chardata"`
+}
+
+// junitTime formats a duration in seconds as expected by JUnit consumers
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
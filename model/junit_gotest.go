@@ -0,0 +1,256 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// GoTestEvent represents a line of the go test -json output.
+// {"Time":"...","Action":"pass","Package":"pkg","Test":"TestFoo","Elapsed":0.01}
+//
+// References:
+// https://pkg.go.dev/cmd/test2json
+type GoTestEvent struct {
+	Time        time.Time `json:"Time"`
+	Action      string    `json:"Action"`
+	Package     string    `json:"Package"`
+	ImportPath  string    `json:"ImportPath"`
+	Test        string    `json:"Test"`
+	Elapsed     float64   `json:"Elapsed"`
+	Output      string    `json:"Output"`
+	FailedBuild string    `json:"FailedBuild"`
+}
+
+const (
+	goTestActionRun         = "run"
+	goTestActionOutput      = "output"
+	goTestActionPass        = "pass"
+	goTestActionFail        = "fail"
+	goTestActionSkip        = "skip"
+	goTestActionBench       = "bench"
+	goTestActionBuildOutput = "build-output"
+	goTestActionBuildFail   = "build-fail"
+
+	goTestBuildFailed   = "[build failed]"
+	goTestPackageFailed = "[package failed]"
+)
+
+type goTestCase struct {
+	name    string
+	result  string
+	elapsed float64
+	output  strings.Builder
+}
+
+type goTestPackage struct {
+	name        string
+	start       time.Time
+	result      string
+	elapsed     float64
+	failedBuild string
+	output      strings.Builder
+	tests       []*goTestCase
+	running     map[string]*goTestCase
+}
+
+// GoTestConverter converts go test -json output into a JUnit report, one test suite per package.
+// Several outputs, e.g. from parallel jobs, can be parsed into the same report.
+type GoTestConverter struct {
+	packages    []*goTestPackage
+	packageMap  map[string]*goTestPackage
+	buildOutput map[string]*strings.Builder
+}
+
+// NewGoTestConverter creates an empty converter
+func NewGoTestConverter() *GoTestConverter {
+	return &GoTestConverter{
+		packageMap:  make(map[string]*goTestPackage),
+		buildOutput: make(map[string]*strings.Builder),
+	}
+}
+
+// Parse reads go test -json events, lines that are not JSON events are ignored
+func (c *GoTestConverter) Parse(in io.Reader) error {
+	r := bufio.NewReader(in)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var event GoTestEvent
+			if bytes.HasPrefix(bytes.TrimSpace(line), []byte("{")) && json.Unmarshal(line, &event) == nil {
+				c.handle(&event)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *GoTestConverter) pkg(name string) *goTestPackage {
+	pkg := c.packageMap[name]
+	if pkg == nil {
+		pkg = &goTestPackage{name: name, running: make(map[string]*goTestCase)}
+		c.packageMap[name] = pkg
+		c.packages = append(c.packages, pkg)
+	}
+	return pkg
+}
+
+func (c *GoTestConverter) handle(event *GoTestEvent) {
+	switch event.Action {
+	case goTestActionBuildOutput:
+		if c.buildOutput[event.ImportPath] == nil {
+			c.buildOutput[event.ImportPath] = &strings.Builder{}
+		}
+		c.buildOutput[event.ImportPath].WriteString(event.Output)
+		return
+	case goTestActionBuildFail:
+		return
+	}
+
+	if event.Package == "" {
+		return
+	}
+
+	pkg := c.pkg(event.Package)
+	if pkg.start.IsZero() {
+		pkg.start = event.Time
+	}
+
+	if event.Test == "" {
+		switch event.Action {
+		case goTestActionOutput:
+			pkg.output.WriteString(event.Output)
+		case goTestActionPass, goTestActionFail, goTestActionSkip:
+			pkg.result = event.Action
+			pkg.elapsed = event.Elapsed
+			if event.FailedBuild != "" {
+				pkg.failedBuild = event.FailedBuild
+			}
+		}
+		return
+	}
+
+	test := pkg.running[event.Test]
+	// A test is run again with -count, or its run event was lost in a truncated output
+	if test == nil || (event.Action == goTestActionRun && test.result != "") {
+		test = &goTestCase{name: event.Test}
+		pkg.running[event.Test] = test
+		pkg.tests = append(pkg.tests, test)
+	}
+
+	switch event.Action {
+	case goTestActionOutput:
+		test.output.WriteString(event.Output)
+	case goTestActionPass, goTestActionFail, goTestActionSkip:
+		test.result = event.Action
+		test.elapsed = event.Elapsed
+	case goTestActionBench:
+		test.result = goTestActionPass
+		test.elapsed = event.Elapsed
+	}
+}
+
+// TestSuites returns the JUnit report of every parsed package
+func (c *GoTestConverter) TestSuites() *JUnitTestSuites {
+	suites := &JUnitTestSuites{Suites: make([]*JUnitTestSuite, 0)}
+
+	elapsed := 0.0
+	for _, pkg := range c.packages {
+		suite := c.testSuite(pkg)
+
+		suites.Suites = append(suites.Suites, suite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		elapsed += pkg.elapsed
+	}
+	suites.Time = junitTime(elapsed)
+
+	return suites
+}
+
+func (c *GoTestConverter) testSuite(pkg *goTestPackage) *JUnitTestSuite {
+	suite := &JUnitTestSuite{
+		Name:      pkg.name,
+		Time:      junitTime(pkg.elapsed),
+		TestCases: make([]*JUnitTestCase, 0),
+		SystemOut: pkg.output.String(),
+	}
+
+	if !pkg.start.IsZero() {
+		suite.Timestamp = pkg.start.Format(time.RFC3339)
+	}
+
+	packageOutput := pkg.output.String()
+	failed := 0
+	for _, test := range pkg.tests {
+		testCase := &JUnitTestCase{
+			Name:      test.name,
+			Classname: pkg.name,
+			Time:      junitTime(test.elapsed),
+		}
+
+		switch test.result {
+		case goTestActionPass:
+		case goTestActionSkip:
+			testCase.Skipped = &JUnitResult{Message: "Skipped", Contents: test.output.String()}
+			suite.Skipped++
+		case goTestActionFail:
+			testCase.Failure = &JUnitResult{Message: "Failed", Contents: test.output.String()}
+			failed++
+		default:
+			// The test never completed, the test binary panicked or timed out while it was running
+			output := test.output.String() + packageOutput
+			message := "Test did not complete"
+			if strings.Contains(output, "panic: test timed out") {
+				message = "Test timed out"
+			} else if strings.Contains(output, "panic:") {
+				message = "Test panicked"
+			}
+			testCase.Failure = &JUnitResult{Message: message, Contents: output}
+			failed++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// Failures outside of tests, e.g. a build failure, a failing TestMain or a panic in an init function,
+	// are errors of the package rather than failures of a test
+	errored := 0
+	if pkg.result == goTestActionFail && failed == 0 {
+		name := goTestPackageFailed
+		message := "Package failed"
+		output := packageOutput
+		if pkg.failedBuild != "" {
+			name = goTestBuildFailed
+			message = "Build failed"
+			if buildOutput := c.buildOutput[pkg.failedBuild]; buildOutput != nil {
+				output = buildOutput.String() + output
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, &JUnitTestCase{
+			Name:      name,
+			Classname: pkg.name,
+			Time:      junitTime(pkg.elapsed),
+			Error:     &JUnitResult{Message: message, Contents: output},
+		})
+		errored++
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suite.Failures = failed
+	suite.Errors = errored
+
+	return suite
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+const goTestJSON = `{"Action":"start","Package":"example.com/pkg"}
+{"Action":"run","Package":"example.com/pkg","Test":"TestPass"}
+{"Action":"output","Package":"example.com/pkg","Test":"TestPass","Output":"--- PASS: TestPass (0.01s)\n"}
+{"Action":"pass","Package":"example.com/pkg","Test":"TestPass","Elapsed":0.01}
+{"Action":"run","Package":"example.com/pkg","Test":"TestSub"}
+{"Action":"run","Package":"example.com/pkg","Test":"TestSub/bad"}
+{"Action":"output","Package":"example.com/pkg","Test":"TestSub/bad","Output":"    pkg_test.go:10: bad sub\n"}
+{"Action":"fail","Package":"example.com/pkg","Test":"TestSub/bad","Elapsed":0}
+{"Action":"fail","Package":"example.com/pkg","Test":"TestSub","Elapsed":0}
+{"Action":"run","Package":"example.com/pkg","Test":"TestSkip"}
+{"Action":"skip","Package":"example.com/pkg","Test":"TestSkip","Elapsed":0}
+{"Action":"run","Package":"example.com/pkg","Test":"TestSlow"}
+{"Action":"output","Package":"example.com/pkg","Test":"TestSlow","Output":"panic: test timed out after 1s\n"}
+{"Action":"output","Package":"example.com/pkg","Output":"FAIL\texample.com/pkg\t1.005s\n"}
+{"Action":"fail","Package":"example.com/pkg","Elapsed":1.005}
+not a json line
+{"ImportPath":"example.com/broken [example.com/broken.test]","Action":"build-output","Output":"broken_test.go:3:27: undefined: undefined\n"}
+{"ImportPath":"example.com/broken [example.com/broken.test]","Action":"build-fail"}
+{"Action":"start","Package":"example.com/broken"}
+{"Action":"fail","Package":"example.com/broken","Elapsed":0,"FailedBuild":"example.com/broken [example.com/broken.test]"}
+{"Action":"start","Package":"example.com/setup"}
+{"Action":"output","Package":"example.com/setup","Output":"panic: missing configuration\n"}
+{"Action":"output","Package":"example.com/setup","Output":"FAIL\texample.com/setup\t0.002s\n"}
+{"Action":"fail","Package":"example.com/setup","Elapsed":0.002}
+`
+
+func TestGoTestConverter(t *testing.T) {
+	converter := NewGoTestConverter()
+	if err := converter.Parse(strings.NewReader(goTestJSON)); err != nil {
+		t.Fatal(err)
+	}
+
+	suites := converter.TestSuites()
+	if len(suites.Suites) != 3 {
+		t.Fatalf("expected 3 suites, got %d", len(suites.Suites))
+	}
+
+	if suites.Tests != 7 || suites.Failures != 3 || suites.Errors != 2 || suites.Skipped != 1 {
+		t.Errorf("unexpected totals tests (%d) failures (%d) errors (%d) skipped (%d)", suites.Tests, suites.Failures, suites.Errors, suites.Skipped)
+	}
+
+	// Failures outside of tests are errors of their package
+	for _, suite := range suites.Suites[1:] {
+		if suite.Tests != 1 || suite.Failures != 0 || suite.Errors != 1 {
+			t.Errorf("unexpected totals of %s tests (%d) failures (%d) errors (%d)", suite.Name, suite.Tests, suite.Failures, suite.Errors)
+		}
+	}
+
+	results := make(map[string]*JUnitTestCase)
+	for _, suite := range suites.Suites {
+		for _, testCase := range suite.TestCases {
+			results[testCase.Name] = testCase
+		}
+	}
+
+	if results["TestPass"].Failure != nil || results["TestPass"].Time != "0.010" {
+		t.Errorf("unexpected passed test %+v", results["TestPass"])
+	}
+
+	if results["TestSub/bad"].Failure == nil || !strings.Contains(results["TestSub/bad"].Failure.Contents, "bad sub") {
+		t.Errorf("expected the subtest to fail with its output")
+	}
+
+	if results["TestSkip"].Skipped == nil {
+		t.Errorf("expected the test to be skipped")
+	}
+
+	if results["TestSlow"].Failure == nil || results["TestSlow"].Failure.Message != "Test timed out" {
+		t.Errorf("expected the test to time out")
+	}
+
+	if results[goTestBuildFailed].Error == nil || !strings.Contains(results[goTestBuildFailed].Error.Contents, "undefined") {
+		t.Errorf("expected the build failure to be reported as an error with its output")
+	}
+
+	if results[goTestPackageFailed].Error == nil || !strings.Contains(results[goTestPackageFailed].Error.Contents, "missing configuration") {
+		t.Errorf("expected the panic in an init function to be reported as an error with its output")
+	}
+}
@@ -0,0 +1,35 @@
+package branch
+
+func Sign(x int) int {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	}
+	return 0
+}
+
+func Clamp(x int) int {
+	if x > 10 {
+		x = 10
+	}
+	return x
+}
+
+func Kind(x interface{}) string {
+	switch x.(type) {
+	case int:
+		return "int"
+	case string:
+		return "string"
+	}
+	return "other"
+}
+
+func Sum(xs []int) int {
+	s := 0
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
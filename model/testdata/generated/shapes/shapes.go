@@ -0,0 +1,30 @@
+package shapes
+
+// Square is a square of a given side
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Circle is a circle of a given radius
+type Circle struct {
+	Radius float64
+}
+
+// Area returns the area of the circle
+func (c Circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+// Total returns the sum of the areas
+func Total(areas ...float64) float64 {
+	total := 0.0
+	for _, area := range areas {
+		total += area
+	}
+	return total
+}
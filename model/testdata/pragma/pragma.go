@@ -0,0 +1,31 @@
+package pragma
+
+import "errors"
+
+// Divide returns a divided by b
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	//coverage:ignore-start defensive, b is never negative
+	if b < 0 {
+		return -a / -b, nil
+	}
+	//coverage:ignore-end
+	return a / b, nil
+}
+
+// mustDivide panics on errors
+//
+//coverage:ignore unreachable in production
+func mustDivide(a, b int) int {
+	result, err := Divide(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func half(a int) int { //coverage:ignore
+	return a / 2
+}
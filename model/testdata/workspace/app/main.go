@@ -0,0 +1,14 @@
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(greeting("world"))
+}
+
+func greeting(name string) string {
+	if name == "" {
+		return "Hello!"
+	}
+	return "Hello, " + name + "!"
+}
@@ -0,0 +1,6 @@
+package util
+
+// Quote returns the text between double quotes
+func Quote(text string) string {
+	return `"` + text + `"`
+}
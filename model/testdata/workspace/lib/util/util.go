@@ -0,0 +1,9 @@
+package util
+
+// Max returns the largest of two numbers
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}